@@ -0,0 +1,86 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A FieldDiff describes a field whose value differs between two instances of
+// a specification.
+type FieldDiff struct {
+	FieldName string
+	EnvKey    string
+	OldValue  string
+	NewValue  string
+}
+
+// DiffSpecs compares two instances of the same specification, such as before
+// and after Reload, and returns the fields whose values differ, in field order.
+// Values are compared as formatted by fmt, and the values of fields tagged
+// `secret:"true"` are redacted in the result.
+func DiffSpecs(prefix string, a, b interface{}) ([]FieldDiff, error) {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return nil, fmt.Errorf("envconfig.DiffSpecs: cannot compare %T to %T", a, b)
+	}
+
+	typ := reflect.TypeOf(a)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		return nil, invalidSpecError(a)
+	}
+
+	// Gather the fields of a fresh value, as gatherInfo allocates nil nested
+	// structs, and read a and b without allocating anything.
+	infos, err := gatherInfo(prefix, reflect.New(typ.Elem()).Interface(), newOptions(nil))
+	if err != nil {
+		return nil, err
+	}
+	av, bv := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+
+	var diffs []FieldDiff
+	for _, info := range infos {
+		oldValue, newValue := diffValue(lookupField(av, info.Index)), diffValue(lookupField(bv, info.Index))
+		if oldValue == newValue {
+			continue
+		}
+		if isTrue(info.Tags.Get("secret")) {
+			oldValue, newValue = redacted, redacted
+		}
+		diffs = append(diffs, FieldDiff{
+			FieldName: info.Name,
+			EnvKey:    info.Key,
+			OldValue:  oldValue,
+			NewValue:  newValue,
+		})
+	}
+	return diffs, nil
+}
+
+// lookupField is like fieldByIndex, but leaves nil nested structs on the way
+// to the field alone, returning the zero value of the field instead.
+func lookupField(s reflect.Value, index []int) reflect.Value {
+	f := s
+	for i, x := range index {
+		if i > 0 {
+			for f.Kind() == reflect.Ptr {
+				if f.IsNil() {
+					return fieldByIndex(reflect.New(s.Type()).Elem(), index)
+				}
+				f = f.Elem()
+			}
+		}
+		f = f.Field(x)
+	}
+	return f
+}
+
+// diffValue formats field for comparison, following pointers so that equal
+// values behind different pointers compare equal.
+func diffValue(field reflect.Value) string {
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return "<nil>"
+		}
+		field = field.Elem()
+	}
+	return fmt.Sprintf("%v", field.Interface())
+}
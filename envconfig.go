@@ -5,32 +5,60 @@
 package envconfig
 
 import (
+	"context"
 	"encoding"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"log/slog"
+	"math/big"
 	"os"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 // ErrInvalidSpecification indicates that a specification is of the wrong type.
+// The errors returned wrap it with the actual type, so compare them with
+// errors.Is.
 var ErrInvalidSpecification = errors.New("specification must be a struct pointer")
 
+// invalidSpecError reports the type of a specification that is not a struct
+// pointer. It matches ErrInvalidSpecification with errors.Is.
+func invalidSpecError(spec interface{}) error {
+	return fmt.Errorf("envconfig: spec must be a pointer to a struct, got %T: %w", spec, ErrInvalidSpecification)
+}
+
+// ErrCircularReference indicates that a specification contains a pointer to a
+// struct type that encloses it, which would nest without end.
+var ErrCircularReference = errors.New("circular reference in specification")
+
+// ErrConflictingTags indicates that a field has struct tags that contradict
+// each other, such as being both required and ignored.
+var ErrConflictingTags = errors.New("conflicting struct tags")
+
 var gatherRegexp = regexp.MustCompile("([^A-Z]+|[A-Z]+[^A-Z]+|[A-Z]+)")
 var acronymRegexp = regexp.MustCompile("([A-Z]+)([A-Z][^A-Z]+)")
 
 // A ParseError occurs when an environment variable cannot be converted to
 // the type required by a struct field during assignment.
+//
+// Value is the value of the environment variable as it was read, before any
+// transformation. It is part of the error message, unless the field is tagged
+// `secret:"true"`.
 type ParseError struct {
 	KeyName   string
 	FieldName string
 	TypeName  string
 	Value     string
 	Err       error
+
+	// secret leaves Value and Err out of the error message.
+	secret bool
 }
 
 // Decoder has the same semantics as Setter, but takes higher precedence.
@@ -39,65 +67,188 @@ type Decoder interface {
 	Decode(value string) error
 }
 
-// Setter is implemented by types can self-deserialize values.
-// Any type that implements flag.Value also implements Setter.
+// ContextSetter is like Setter, but also receives the context given to
+// ProcessContext. It takes precedence over Setter.
+type ContextSetter interface {
+	SetContext(ctx context.Context, value string) error
+}
+
+// Setter is implemented by types that can self-deserialize values.
+// Any type that implements flag.Value also implements Setter. Types can assert
+// conformance at compile time with var _ envconfig.Setter = (*MyType)(nil).
 type Setter interface {
 	Set(value string) error
 }
 
 func (e *ParseError) Error() string {
+	if e.secret {
+		// Leave out the details as well, errors such as those of strconv
+		// quote the value, possibly in another form.
+		return fmt.Sprintf("envconfig.Process: assigning %[1]s to %[2]s: invalid value for type %[3]s", e.KeyName, e.FieldName, e.TypeName)
+	}
 	return fmt.Sprintf("envconfig.Process: assigning %[1]s to %[2]s: converting '%[3]s' to type %[4]s. details: %[5]s", e.KeyName, e.FieldName, e.Value, e.TypeName, e.Err)
 }
 
+// A RequiredError occurs when a required environment variable has no value.
+// EnvKey is the full name of the variable, including the prefix and the names
+// of all enclosing structs.
+type RequiredError struct {
+	FieldName string
+	EnvKey    string
+}
+
+func (e *RequiredError) Error() string {
+	return fmt.Sprintf("required key %s missing value", e.EnvKey)
+}
+
+// A SchemaVersionMismatchError occurs when the schema version field of a
+// specification does not hold the version expected by WithSchemaVersion.
+type SchemaVersionMismatchError struct {
+	EnvKey   string
+	Expected string
+	Actual   string
+}
+
+func (e *SchemaVersionMismatchError) Error() string {
+	return fmt.Sprintf("envconfig.Process: schema version %s is %q, expected %q", e.EnvKey, e.Actual, e.Expected)
+}
+
+// A Warning is a non-fatal advisory message about a configuration variable.
+type Warning struct {
+	FieldName string
+	EnvKey    string
+	Message   string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.EnvKey, w.Message)
+}
+
 // varInfo maintains information about the configuration variable
 type varInfo struct {
-	Name  string
-	Alt   string
-	Key   string
-	Field reflect.Value
-	Tags  reflect.StructTag
+	Name    string
+	Alt     string
+	Key     string
+	Aliases []string
+	Index   []int
+	Field   reflect.Value
+	Tags    reflect.StructTag
+}
+
+// fieldMeta is the part of a varInfo that only depends on the type of the
+// specification, and can therefore be cached between calls.
+type fieldMeta struct {
+	Index   []int
+	Name    string
+	Alt     string
+	Key     string
+	Aliases []string
+	Tags    reflect.StructTag
 }
 
+// fieldCacheKey identifies everything the fields of a specification type are
+// derived from.
+type fieldCacheKey struct {
+	Type      reflect.Type
+	Prefix    string
+	Separator string
+	TagNames  string
+}
+
+// fieldCache maps a fieldCacheKey to the []fieldMeta derived from it.
+var fieldCache sync.Map
+
 // GatherInfo gathers information about the specified struct
-func gatherInfo(prefix string, spec interface{}) ([]varInfo, error) {
+func gatherInfo(prefix string, spec interface{}, o *options) ([]varInfo, error) {
 	s := reflect.ValueOf(spec)
 
 	if s.Kind() != reflect.Ptr {
-		return nil, ErrInvalidSpecification
+		return nil, invalidSpecError(spec)
 	}
 	s = s.Elem()
 	if s.Kind() != reflect.Struct {
-		return nil, ErrInvalidSpecification
+		return nil, invalidSpecError(spec)
+	}
+
+	key := fieldCacheKey{
+		Type:      s.Type(),
+		Prefix:    prefix,
+		Separator: o.separator,
+		TagNames:  tagNamesKey(o.tagNames),
+	}
+	var metas []fieldMeta
+	if cached, ok := fieldCache.Load(key); ok {
+		metas = cached.([]fieldMeta)
+	} else {
+		var err error
+		metas, err = gatherFields(s.Type(), prefix, nil, make(map[reflect.Type]bool), o)
+		if err != nil {
+			return nil, err
+		}
+		fieldCache.Store(key, metas)
 	}
-	typeOfSpec := s.Type()
 
-	// over allocate an info array, we will extend if needed later
-	infos := make([]varInfo, 0, s.NumField())
-	for i := 0; i < s.NumField(); i++ {
-		f := s.Field(i)
-		ftype := typeOfSpec.Field(i)
-		if !f.CanSet() || isTrue(ftype.Tag.Get("ignored")) {
+	infos := make([]varInfo, 0, len(metas))
+	for _, m := range metas {
+		if o.isIgnoredKey(m.Key) || o.isFiltered(m.Name, m.Key) {
 			continue
 		}
+		infos = append(infos, varInfo{
+			Name:    m.Name,
+			Alt:     m.Alt,
+			Key:     m.Key,
+			Aliases: m.Aliases,
+			Index:   m.Index,
+			Field:   fieldByIndex(s, m.Index),
+			Tags:    m.Tags,
+		})
+	}
+	return infos, nil
+}
 
-		for f.Kind() == reflect.Ptr {
-			if f.IsNil() {
-				if f.Type().Elem().Kind() != reflect.Struct {
-					// nil pointer to a non-struct: leave it alone
-					break
-				}
-				// nil pointer to struct: create a zero instance
-				f.Set(reflect.New(f.Type().Elem()))
+// gatherFields gathers the fields of the specified struct type, index is the
+// path of field indexes leading to the struct. visited holds the struct types
+// along that path, to detect structs that contain pointers to themselves.
+func gatherFields(typ reflect.Type, prefix string, index []int, visited map[reflect.Type]bool, o *options) ([]fieldMeta, error) {
+	if visited[typ] {
+		return nil, fmt.Errorf("%w: %s", ErrCircularReference, typ)
+	}
+	visited[typ] = true
+	defer delete(visited, typ)
+
+	// over allocate a field array, we will extend if needed later
+	metas := make([]fieldMeta, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		ftype := typ.Field(i)
+		if ftype.PkgPath != "" {
+			continue
+		}
+		tag := renameTags(ftype.Tag, o.tagNames)
+		if isTrue(tag.Get("ignored")) {
+			if isTrue(tag.Get("required")) {
+				return nil, fmt.Errorf("%w: field %s is both required and ignored", ErrConflictingTags, ftype.Name)
 			}
-			f = f.Elem()
+			continue
+		}
+
+		t := ftype.Type
+		for t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+			t = t.Elem()
 		}
 
 		// Capture information about the config variable
-		info := varInfo{
+		meta := fieldMeta{
+			Index: append(index[:len(index):len(index)], i),
 			Name:  ftype.Name,
-			Field: f,
-			Tags:  ftype.Tag,
-			Alt:   strings.ToUpper(ftype.Tag.Get("envconfig")),
+			Tags:  tag,
+			Alt:   strings.ToUpper(tag.Get("envconfig")),
+		}
+		nested := t.Kind() == reflect.Struct && !implementsInterface(t)
+
+		// On nested structs, the prefix tag makes it explicit that the name is
+		// a prefix for the fields inside. The envconfig tag wins if both are set.
+		if nested && meta.Alt == "" {
+			meta.Alt = strings.ToUpper(tag.Get("prefix"))
 		}
 
 		// The reMarkable version of this package behaves slightly different than
@@ -108,64 +259,101 @@ func gatherInfo(prefix string, spec interface{}) ([]varInfo, error) {
 		//
 		// We also do not attempt to locate non-prefixed versions of variables, if
 		// the prefixed one is not found.
-		info.Key = info.Alt
-		if prefix != "" && info.Key != "" {
-			info.Key = fmt.Sprintf("%s_%s", strings.ToUpper(prefix), info.Key)
+		meta.Key = meta.Alt
+		if prefix != "" && meta.Key != "" {
+			meta.Key = strings.ToUpper(prefix) + o.separator + meta.Key
 		}
-		if info.Key != "" {
-			infos = append(infos, info)
+
+		// honor Decode if present
+		if nested {
+			innerPrefix := prefix
+			if !ftype.Anonymous {
+				innerPrefix = meta.Key
+			}
+
+			embedded, err := gatherFields(t, innerPrefix, meta.Index, visited, o)
+			if err != nil {
+				return nil, err
+			}
+			metas = append(metas, embedded...)
+
+			continue
 		}
 
-		if f.Kind() == reflect.Struct {
-			// honor Decode if present
-			if decoderFrom(f) == nil && setterFrom(f) == nil && textUnmarshaler(f) == nil && binaryUnmarshaler(f) == nil {
-				innerPrefix := prefix
-				if !ftype.Anonymous {
-					innerPrefix = info.Key
+		if meta.Key != "" {
+			if aliases := tag.Get("alias"); aliases != "" {
+				for _, alias := range strings.Split(aliases, ",") {
+					alias = strings.ToUpper(strings.TrimSpace(alias))
+					if prefix != "" {
+						alias = strings.ToUpper(prefix) + o.separator + alias
+					}
+					meta.Aliases = append(meta.Aliases, alias)
 				}
+			}
+			metas = append(metas, meta)
+		}
+	}
+	return metas, nil
+}
 
-				embeddedPtr := f.Addr().Interface()
-				embeddedInfos, err := gatherInfo(innerPrefix, embeddedPtr)
-				if err != nil {
-					return nil, err
-				}
-				// Since we do not append an info unless the key is explicitly specified,
-				// we shouldn't pop it here either, since there is nothing to replace.
-				if info.Key != "" {
-					infos = infos[:len(infos)-1]
+// fieldByIndex returns the nested field of s given by index, creating zero
+// instances for nil pointers to structs along the way.
+func fieldByIndex(s reflect.Value, index []int) reflect.Value {
+	f := s
+	for _, i := range index {
+		f = f.Field(i)
+		for f.Kind() == reflect.Ptr {
+			if f.IsNil() {
+				if f.Type().Elem().Kind() != reflect.Struct {
+					// nil pointer to a non-struct: leave it alone
+					break
 				}
-				infos = append(infos, embeddedInfos...)
-
-				continue
+				// nil pointer to struct: create a zero instance
+				f.Set(reflect.New(f.Type().Elem()))
 			}
+			f = f.Elem()
 		}
 	}
-	return infos, nil
+	return f
 }
 
 // CheckDisallowed checks that no environment variables with the prefix are set
 // that we don't know how or want to parse. This is likely only meaningful with
-// a non-empty prefix.
-func CheckDisallowed(prefix string, spec interface{}) error {
-	infos, err := gatherInfo(prefix, spec)
+// a non-empty prefix. The names listed in alias tags count as known, so that
+// variables can be renamed without failing the check in the meantime.
+func CheckDisallowed(prefix string, spec interface{}, opts ...Option) error {
+	return checkDisallowed(prefix, spec, newOptions(opts))
+}
+
+func checkDisallowed(prefix string, spec interface{}, o *options) error {
+	prefix = o.resolvePrefix(prefix)
+	infos, err := gatherInfo(prefix, spec, o)
 	if err != nil {
 		return err
 	}
 
+	// Names in the environment are compared to the names of the
+	// specification as transformed by WithKeyTransformer.
 	vars := make(map[string]struct{})
 	for _, info := range infos {
-		vars[info.Key] = struct{}{}
+		vars[o.envKey(info.Key)] = struct{}{}
+		for _, alias := range info.Aliases {
+			vars[o.envKey(alias)] = struct{}{}
+		}
 	}
 
 	if prefix != "" {
-		prefix = strings.ToUpper(prefix) + "_"
+		prefix = o.envKey(strings.ToUpper(prefix) + o.separator)
 	}
 
-	for _, env := range os.Environ() {
+	for _, env := range o.env.Environ() {
 		if !strings.HasPrefix(env, prefix) {
 			continue
 		}
 		v := strings.SplitN(env, "=", 2)[0]
+		if o.isIgnoredEnvKey(v) {
+			continue
+		}
 		if _, found := vars[v]; !found {
 			return fmt.Errorf("unknown environment variable %s", v)
 		}
@@ -176,46 +364,346 @@ func CheckDisallowed(prefix string, spec interface{}) error {
 
 // Process populates the specified struct based on environment variables
 func Process(prefix string, spec interface{}) error {
-	infos, err := gatherInfo(prefix, spec)
+	_, err := process(prefix, spec, newOptions(nil))
+	return err
+}
+
+// ProcessWithOptions is the same as Process, but with its behavior adjusted by
+// the given options.
+func ProcessWithOptions(prefix string, spec interface{}, opts ...Option) error {
+	_, err := process(prefix, spec, newOptions(opts))
+	return err
+}
+
+// ProcessStrict is the same as Process, but also fails like CheckDisallowed if
+// any environment variable with the prefix is unknown to the specification.
+// When a field fails to process as well, both errors are returned combined
+// with errors.Join, the error of the field first.
+func ProcessStrict(prefix string, spec interface{}, opts ...Option) error {
+	return ProcessWithOptions(prefix, spec, append(opts, WithStrictMode(true))...)
+}
+
+// ProcessAndValidate is the same as Process, but if spec implements Validator
+// its Validate method is called once all fields have been populated.
+func ProcessAndValidate(prefix string, spec interface{}, opts ...Option) error {
+	return ProcessWithOptions(prefix, spec, append(opts, WithValidation(true))...)
+}
+
+// ProcessPartial is the same as Process, except that required fields without
+// a value are left at their zero value instead of causing an error.
+func ProcessPartial(prefix string, spec interface{}, opts ...Option) error {
+	o := newOptions(opts)
+	o.partial = true
+	_, err := process(prefix, spec, o)
+	return err
+}
+
+// Reload re-processes a specification that has already been populated, for
+// services that pick up configuration changes without restarting. Unlike
+// Process, fields whose environment variables are unset keep their current
+// values instead of reverting to their defaults, and are not reported as
+// missing when required.
+func Reload(prefix string, spec interface{}, opts ...Option) error {
+	o := newOptions(opts)
+	o.reload = true
+	_, err := process(prefix, spec, o)
+	return err
+}
+
+// ProcessWithWarnings is the same as Process, but also returns advisory
+// warnings about the configuration that are not severe enough to fail on: a
+// required field falling back to its default, a field read from one of its
+// deprecated aliases, and a value with leading or trailing whitespace, which
+// is kept as is.
+func ProcessWithWarnings(prefix string, spec interface{}, opts ...Option) ([]Warning, error) {
+	return process(prefix, spec, newOptions(opts))
+}
+
+// ProcessContext is the same as Process, but passes ctx on to fields
+// implementing ContextSetter. If ctx is done before all fields have been
+// populated, processing stops and ctx.Err() is returned.
+func ProcessContext(ctx context.Context, prefix string, spec interface{}, opts ...Option) error {
+	o := newOptions(opts)
+	o.ctx = ctx
+	_, err := process(prefix, spec, o)
+	return err
+}
+
+func process(prefix string, spec interface{}, o *options) ([]Warning, error) {
+	prefix = o.resolvePrefix(prefix)
+	infos, err := gatherInfo(prefix, spec, o)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings, err := processInfos(infos, o)
+	if err != nil && o.ctx.Err() != nil {
+		return warnings, err
+	}
+	// Report unknown variables along with the errors of known ones, which
+	// come first.
+	if o.strict {
+		if derr := checkDisallowed(prefix, spec, o); derr != nil {
+			if err != nil {
+				return warnings, errors.Join(err, derr)
+			}
+			return warnings, derr
+		}
+	}
+	if err != nil {
+		return warnings, err
+	}
+
+	if o.schemaVersion != nil {
+		if err := checkSchemaVersion(prefix, infos, o); err != nil {
+			return warnings, err
+		}
+	}
 
+	if p, ok := spec.(Processable); ok && !o.dryRun {
+		if err := p.PostProcess(); err != nil {
+			return warnings, &PostProcessError{Err: err}
+		}
+	}
+
+	if o.validate {
+		if v, ok := spec.(Validator); ok {
+			if err := v.Validate(); err != nil {
+				return warnings, &ValidationError{Err: err}
+			}
+		}
+	}
+
+	if o.logger != nil {
+		args := make([]interface{}, 0, 2*len(infos))
+		for _, info := range infos {
+			args = append(args, slog.String(info.Key, displayValue(info)))
+		}
+		o.logger.Info("configuration loaded", args...)
+	}
+
+	return warnings, nil
+}
+
+// processInfos populates the fields of infos from the environment, stopping
+// at the first error.
+func processInfos(infos []varInfo, o *options) ([]Warning, error) {
+	var warnings []Warning
 	for _, info := range infos {
+		if err := o.ctx.Err(); err != nil {
+			return warnings, err
+		}
 
 		// Get the value from the environment variable. In the reMarkable fork,
 		// we do not differentiate between explicitly set empty values, and
 		// values missing altogether. If a value is required, and it is empty,
 		// that is considered an error.
-		value := os.Getenv(info.Key)
+		value, key, err := lookupEnv(info, o)
+		if err != nil {
+			return warnings, err
+		}
+		if o.reload && value == "" {
+			continue
+		}
+		if value != "" && key != info.Key {
+			warnings = append(warnings, Warning{
+				FieldName: info.Name,
+				EnvKey:    info.Key,
+				Message:   fmt.Sprintf("using deprecated alias %s", key),
+			})
+		}
+		if value != "" && strings.TrimSpace(value) != value {
+			warnings = append(warnings, Warning{
+				FieldName: info.Name,
+				EnvKey:    info.Key,
+				Message:   "value has leading or trailing whitespace",
+			})
+		}
 
-		def := info.Tags.Get("default")
+		req := info.Tags.Get("required")
+		def, err := defaultValue(info, o)
+		if err != nil {
+			return warnings, err
+		}
+		if value == "" && !isTrue(req) && o.missingHandler != nil {
+			o.missingHandler(info.Key, info.Name, def)
+		}
+		source := sourceEnv
 		if def != "" && value == "" {
 			value = def
+			source = sourceDefault
+			if isTrue(req) {
+				warnings = append(warnings, Warning{
+					FieldName: info.Name,
+					EnvKey:    info.Key,
+					Message:   "required value not set, using default",
+				})
+			}
+		}
+
+		if o.requireNonEmpty && isTrue(req) && strings.TrimSpace(value) == "" {
+			value = ""
 		}
 
-		req := info.Tags.Get("required")
 		if value == "" {
-			if isTrue(req) {
-				key := info.Key
-				if info.Alt != "" {
-					key = info.Alt
+			// Without a default either, WithRequireAllOrDefault treats every
+			// field as required.
+			if (isTrue(req) || o.requireAll) && !o.partial {
+				return warnings, &RequiredError{
+					FieldName: info.Name,
+					EnvKey:    info.Key,
+				}
+			}
+			// ProcessPartial leaves fields without a value at their zero
+			// value, whatever their length limits.
+			if o.partial {
+				o.audit(info, sourceZero, "")
+				continue
+			}
+			if err := checkLength(0, info.Tags); err != nil {
+				return warnings, &ParseError{
+					KeyName:   info.Key,
+					FieldName: info.Name,
+					TypeName:  info.Field.Type().String(),
+					Value:     value,
+					Err:       err,
+					secret:    isTrue(info.Tags.Get("secret")),
 				}
-				return fmt.Errorf("required key %s missing value", key)
 			}
+			o.audit(info, sourceZero, "")
 			continue
 		}
 
-		err = processField(value, info.Field)
+		err = processVar(value, info, o)
+		if ctxErr := o.ctx.Err(); ctxErr != nil {
+			return warnings, ctxErr
+		}
 		if err != nil {
-			return &ParseError{
+			return warnings, &ParseError{
 				KeyName:   info.Key,
 				FieldName: info.Name,
 				TypeName:  info.Field.Type().String(),
 				Value:     value,
 				Err:       err,
+				secret:    isTrue(info.Tags.Get("secret")),
+			}
+		}
+		if o.requireNonEmpty && isTrue(req) && !o.partial && isEmptyValue(info.Field) {
+			return warnings, &RequiredError{
+				FieldName: info.Name,
+				EnvKey:    info.Key,
 			}
 		}
+		o.audit(info, source, value)
 	}
 
-	return err
+	return warnings, nil
+}
+
+// schemaVersionKey is the unprefixed name of the field checked by
+// WithSchemaVersion.
+const schemaVersionKey = "SCHEMA_VERSION"
+
+// checkSchemaVersion compares the schema version field at the top level of the
+// specification, if any, with the version expected by WithSchemaVersion.
+func checkSchemaVersion(prefix string, infos []varInfo, o *options) error {
+	key := schemaVersionKey
+	if prefix != "" {
+		key = strings.ToUpper(prefix) + o.separator + key
+	}
+	for _, info := range infos {
+		if info.Key != key {
+			continue
+		}
+		actual, err := marshalField(info.Field, info.Tags.Get("separator"))
+		if err != nil {
+			return err
+		}
+		if actual != *o.schemaVersion {
+			return &SchemaVersionMismatchError{EnvKey: info.Key, Expected: *o.schemaVersion, Actual: actual}
+		}
+	}
+	return nil
+}
+
+// ProcessAll processes each of specs in order under the same prefix, for
+// configuration split into several structs. All specs are processed even if
+// some fail, and their errors are combined with errors.Join, so each can still
+// be inspected with errors.As.
+func ProcessAll(prefix string, specs ...interface{}) error {
+	var errs []error
+	for _, spec := range specs {
+		if err := Process(prefix, spec); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// lookupEnv returns the value of the environment variable of info, falling
+// back to its aliases in order, and the name of the variable it was read from.
+func lookupEnv(info varInfo, o *options) (value, key string, err error) {
+	key = info.Key
+	value, err = o.getenv(key)
+	for _, alias := range info.Aliases {
+		if value != "" || err != nil {
+			break
+		}
+		key = alias
+		value, err = o.getenv(alias)
+	}
+	return value, key, err
+}
+
+// defaultValue returns the default of info, which is the value of the
+// variable named by its default_env tag if set, or else its default tag.
+func defaultValue(info varInfo, o *options) (string, error) {
+	if key := info.Tags.Get("default_env"); key != "" {
+		if v, err := o.getenv(key); v != "" || err != nil {
+			return v, err
+		}
+	}
+	return info.Tags.Get("default"), nil
+}
+
+// CheckMissing returns the names of the environment variables that processing
+// the specification would report as missing: those of required fields that
+// are neither set nor have a default. It does not read or populate any field
+// of spec, which makes it suitable for checking a deployment in advance.
+func CheckMissing(prefix string, spec interface{}, opts ...Option) ([]string, error) {
+	typ := reflect.TypeOf(spec)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		return nil, invalidSpecError(spec)
+	}
+
+	o := newOptions(opts)
+	// Work on a fresh value, so that spec is left untouched.
+	infos, err := gatherInfo(o.resolvePrefix(prefix), reflect.New(typ.Elem()).Interface(), o)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, info := range infos {
+		value, _, err := lookupEnv(info, o)
+		if err != nil {
+			return nil, err
+		}
+		if o.requireNonEmpty && strings.TrimSpace(value) == "" {
+			value = ""
+		}
+		def, err := defaultValue(info, o)
+		if err != nil {
+			return nil, err
+		}
+		if value != "" || def != "" {
+			continue
+		}
+		if isTrue(info.Tags.Get("required")) || o.requireAll {
+			missing = append(missing, info.Key)
+		}
+	}
+	return missing, nil
 }
 
 // MustProcess is the same as Process but panics if an error occurs
@@ -225,13 +713,155 @@ func MustProcess(prefix string, spec interface{}) {
 	}
 }
 
-func processField(value string, field reflect.Value) error {
+// processVar populates the field described by info from value, honoring the
+// struct tags that affect how the value is interpreted.
+func processVar(value string, info varInfo, o *options) error {
+	if isTrue(info.Tags.Get("file")) {
+		// The value is a path to a file holding the actual value, like the
+		// secrets Kubernetes mounts into containers.
+		b, err := os.ReadFile(value)
+		if err != nil {
+			return err
+		}
+		value = strings.TrimSuffix(string(b), "\n")
+	}
+
+	if name := info.Tags.Get("transform"); name != "" {
+		v, err := applyTransform(value, name)
+		if err != nil {
+			return err
+		}
+		value = v
+	}
+
+	var err error
+	if name := info.Tags.Get("validate"); name != "" {
+		err = processValidated(value, info.Field, info.Tags.Get("separator"), name, o)
+	} else {
+		err = processField(value, info.Field, info.Tags.Get("separator"), o)
+	}
+	if err != nil {
+		return err
+	}
+
+	if isTrue(info.Tags.Get("unique")) {
+		if err := uniqueSlice(info.Field); err != nil {
+			return err
+		}
+	}
+
+	if info.Tags.Get("minlength") != "" || info.Tags.Get("maxlength") != "" {
+		field := reflect.Indirect(info.Field)
+		switch field.Kind() {
+		case reflect.String:
+			return checkLength(utf8.RuneCountInString(field.String()), info.Tags)
+		case reflect.Slice:
+			return checkLength(field.Len(), info.Tags)
+		default:
+			return fmt.Errorf("length limits require a string or slice, got %s", field.Type())
+		}
+	}
+
+	return nil
+}
+
+// checkLength checks the length of a string or slice, n, against the limits
+// given by the minlength and maxlength tags.
+func checkLength(n int, tags reflect.StructTag) error {
+	if minLen := tags.Get("minlength"); minLen != "" {
+		limit, err := strconv.Atoi(minLen)
+		if err != nil {
+			return fmt.Errorf("invalid minlength tag %q: %w", minLen, err)
+		}
+		if n < limit {
+			return fmt.Errorf("length %d is less than the minimum of %d", n, limit)
+		}
+	}
+	if maxLen := tags.Get("maxlength"); maxLen != "" {
+		limit, err := strconv.Atoi(maxLen)
+		if err != nil {
+			return fmt.Errorf("invalid maxlength tag %q: %w", maxLen, err)
+		}
+		if n > limit {
+			return fmt.Errorf("length %d is more than the maximum of %d", n, limit)
+		}
+	}
+	return nil
+}
+
+// uniqueSlice removes duplicate elements from a slice field, keeping the first
+// occurrence of each element.
+func uniqueSlice(field reflect.Value) error {
+	field = reflect.Indirect(field)
+	if field.Kind() != reflect.Slice {
+		return fmt.Errorf("unique requires a slice, got %s", field.Type())
+	}
+	if !field.Type().Elem().Comparable() {
+		return fmt.Errorf("unique requires comparable elements, got %s", field.Type().Elem())
+	}
+
+	seen := make(map[interface{}]struct{}, field.Len())
+	n := 0
+	for i := 0; i < field.Len(); i++ {
+		elem := field.Index(i)
+		if _, found := seen[elem.Interface()]; found {
+			continue
+		}
+		seen[elem.Interface()] = struct{}{}
+		field.Index(n).Set(elem)
+		n++
+	}
+	field.SetLen(n)
+
+	return nil
+}
+
+// processField converts value to the type of field and populates it. seps
+// holds the separators to use for nested slices and maps, one character per
+// level starting from the outermost, as given by the separator tag.
+func processField(value string, field reflect.Value, seps string, o *options) error {
 	typ := field.Type()
 
+	// big.Int implements encoding.TextUnmarshaler, but guesses the base from
+	// the value, reading "010" as octal. Always parse it as decimal instead.
+	if typ == bigIntType || typ == reflect.PtrTo(bigIntType) {
+		if _, ok := bigValue(field, bigIntType).(*big.Int).SetString(value, 10); !ok {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		return nil
+	}
+	// big.Float would default to the 64 bits of precision of a float64, which
+	// defeats its purpose. Use 256 bits unless a precision is already set.
+	if typ == bigFloatType || typ == reflect.PtrTo(bigFloatType) {
+		f := bigValue(field, bigFloatType).(*big.Float)
+		if f.Prec() == 0 {
+			f.SetPrec(256)
+		}
+		if _, ok := f.SetString(value); !ok {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		return nil
+	}
+
+	// Allocate nil pointers up front, so that methods with pointer receivers
+	// are not called on nil, as for the elements of a []*url.URL.
+	if typ.Kind() == reflect.Ptr && field.IsNil() && field.CanSet() {
+		field.Set(reflect.New(typ.Elem()))
+	}
+
+	// Replace rather than Set the value of an interface with registered
+	// implementations, as the value may be of another implementation.
+	if typ.Kind() == reflect.Interface && o.implementations.has(typ) {
+		return o.implementations.instantiate(value, field)
+	}
+
 	decoder := decoderFrom(field)
 	if decoder != nil {
 		return decoder.Decode(value)
 	}
+	if cs := contextSetterFrom(field); cs != nil {
+		return cs.SetContext(o.ctx, value)
+	}
 	// look for Set method if Decode not defined
 	setter := setterFrom(field)
 	if setter != nil {
@@ -241,6 +871,10 @@ func processField(value string, field reflect.Value) error {
 	if t := textUnmarshaler(field); t != nil {
 		return t.UnmarshalText([]byte(value))
 	}
+	// UnmarshalText with a pointer receiver needs the address of field.
+	if !field.CanAddr() && reflect.PtrTo(typ).Implements(textUnmarshalerType) {
+		return fmt.Errorf("cannot call UnmarshalText on unaddressable value of type %s: it must be addressable or a pointer", typ)
+	}
 
 	if b := binaryUnmarshaler(field); b != nil {
 		return b.UnmarshalBinary([]byte(value))
@@ -269,7 +903,7 @@ func processField(value string, field reflect.Value) error {
 			)
 
 			// check if the value is a "d" (day) duration
-			if strings.HasSuffix(value, "d") {
+			if !o.strictDuration && strings.HasSuffix(value, "d") {
 				if daysInt, err = strconv.ParseInt(strings.TrimSuffix(value, "d"), 10, 64); err != nil {
 					return err
 				}
@@ -293,7 +927,11 @@ func processField(value string, field reflect.Value) error {
 		}
 		field.SetUint(val)
 	case reflect.Bool:
-		val, err := strconv.ParseBool(value)
+		parse := strconv.ParseBool
+		if o.weakBool {
+			parse = parseTagBool
+		}
+		val, err := parse(value)
 		if err != nil {
 			return err
 		}
@@ -304,6 +942,12 @@ func processField(value string, field reflect.Value) error {
 			return err
 		}
 		field.SetFloat(val)
+	case reflect.Complex64, reflect.Complex128:
+		val, err := strconv.ParseComplex(value, typ.Bits())
+		if err != nil {
+			return err
+		}
+		field.SetComplex(val)
 	case reflect.Slice:
 		sl := reflect.MakeSlice(typ, 0, 0)
 		if typ.Elem().Kind() == reflect.Uint8 {
@@ -313,10 +957,11 @@ func processField(value string, field reflect.Value) error {
 			}
 			sl = reflect.ValueOf(b)
 		} else if strings.TrimSpace(value) != "" {
-			vals := strings.Split(value, ",")
+			sep, rest := nextSeparator(seps, ",")
+			vals := strings.Split(value, sep)
 			sl = reflect.MakeSlice(typ, len(vals), len(vals))
 			for i, val := range vals {
-				err := processField(val, sl.Index(i))
+				err := processField(val, sl.Index(i), rest, o)
 				if err != nil {
 					return err
 				}
@@ -326,21 +971,22 @@ func processField(value string, field reflect.Value) error {
 	case reflect.Map:
 		mp := reflect.MakeMap(typ)
 		if strings.TrimSpace(value) != "" {
-			pairs := strings.Split(value, ";")
+			sep, rest := nextSeparator(seps, ";")
+			pairs := strings.Split(value, sep)
 			for _, pair := range pairs {
 				kvpair := strings.Split(pair, ":")
 				if len(kvpair) != 2 {
 					return fmt.Errorf("invalid map item: %q", pair)
 				}
 				k := reflect.New(typ.Key()).Elem()
-				err := processField(kvpair[0], k)
+				err := processField(kvpair[0], k, "", o)
 				if err != nil {
-					return err
+					return fmt.Errorf("invalid map key %q: %w", kvpair[0], err)
 				}
 				v := reflect.New(typ.Elem()).Elem()
-				err = processField(kvpair[1], v)
+				err = processField(kvpair[1], v, rest, o)
 				if err != nil {
-					return err
+					return fmt.Errorf("invalid map value %q: %w", kvpair[1], err)
 				}
 				mp.SetMapIndex(k, v)
 			}
@@ -351,16 +997,60 @@ func processField(value string, field reflect.Value) error {
 	return nil
 }
 
+// isEmptyValue reports whether a populated field still holds no data, such as
+// a string read from an empty file.
+func isEmptyValue(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return field.Len() == 0
+	case reflect.Ptr:
+		return field.IsNil()
+	}
+	return false
+}
+
+// nextSeparator splits the separator for the current level off seps, using
+// def if seps is exhausted.
+func nextSeparator(seps, def string) (string, string) {
+	if seps == "" {
+		return def, ""
+	}
+	_, n := utf8.DecodeRuneInString(seps)
+	return seps[:n], seps[n:]
+}
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+)
+
+// bigValue returns a pointer to the value of field, which is of type typ or a
+// pointer to it, allocating it if needed.
+func bigValue(field reflect.Value, typ reflect.Type) interface{} {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(typ))
+		}
+		return field.Interface()
+	}
+	return field.Addr().Interface()
+}
+
+// interfaceFrom calls fn with a pointer to field if it is addressable, so
+// that methods with pointer receivers can populate it, and then with field
+// itself for methods with value receivers.
 func interfaceFrom(field reflect.Value, fn func(interface{}, *bool)) {
 	// it may be impossible for a struct field to fail this check
 	if !field.CanInterface() {
 		return
 	}
 	var ok bool
-	fn(field.Interface(), &ok)
-	if !ok && field.CanAddr() {
+	if field.CanAddr() {
 		fn(field.Addr().Interface(), &ok)
 	}
+	if !ok {
+		fn(field.Interface(), &ok)
+	}
 }
 
 func decoderFrom(field reflect.Value) (d Decoder) {
@@ -368,6 +1058,11 @@ func decoderFrom(field reflect.Value) (d Decoder) {
 	return d
 }
 
+func contextSetterFrom(field reflect.Value) (cs ContextSetter) {
+	interfaceFrom(field, func(v interface{}, ok *bool) { cs, *ok = v.(ContextSetter) })
+	return cs
+}
+
 func setterFrom(field reflect.Value) (s Setter) {
 	interfaceFrom(field, func(v interface{}, ok *bool) { s, *ok = v.(Setter) })
 	return s
@@ -383,7 +1078,59 @@ func binaryUnmarshaler(field reflect.Value) (b encoding.BinaryUnmarshaler) {
 	return b
 }
 
+// parseTagBool parses a boolean struct tag value, or the value of a bool field
+// with WithWeakBoolParsing. In addition to the values accepted by
+// strconv.ParseBool it accepts "yes", "no", "on" and "off", which are common
+// in Docker Compose and Kubernetes configuration.
+func parseTagBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "yes", "on":
+		return true, nil
+	case "no", "off":
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
+
+// redacted replaces the value of fields tagged `secret:"true"` wherever
+// values are displayed.
+const redacted = "***"
+
+// masker is implemented by types holding credentials, such as
+// types.BearerToken, to format themselves with the credentials masked.
+type masker interface {
+	Masked() string
+}
+
+// displayValue formats the current value of a field for display, redacting
+// secrets. Unlike Marshal, it prefers Masked and then String to MarshalText,
+// as types such as types.RedisDSN mask their credentials in these only.
+func displayValue(info varInfo) string {
+	if isTrue(info.Tags.Get("secret")) {
+		return redacted
+	}
+	field := info.Field
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return ""
+		}
+		field = field.Elem()
+	}
+	var m masker
+	interfaceFrom(field, func(v interface{}, ok *bool) { m, *ok = v.(masker) })
+	if m != nil {
+		return m.Masked()
+	}
+	if s := stringer(field); s != nil {
+		return s.String()
+	}
+	if value, err := marshalField(info.Field, info.Tags.Get("separator")); err == nil {
+		return value
+	}
+	return fmt.Sprint(info.Field.Interface())
+}
+
 func isTrue(s string) bool {
-	b, _ := strconv.ParseBool(s)
+	b, _ := parseTagBool(s)
 	return b
 }
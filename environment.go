@@ -0,0 +1,104 @@
+package envconfig
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+)
+
+// An Environment is a source of environment variables. Processing reads the
+// variables of the process by default, see WithEnvironment for reading them
+// from elsewhere, such as a secret store or a map in a test.
+type Environment interface {
+	// Getenv returns the value of the variable key, or "" if it is not set.
+	Getenv(key string) string
+	// Environ returns all variables in the form "key=value".
+	Environ() []string
+}
+
+// A ContextEnvironment is an Environment whose lookups may block, such as one
+// backed by a secret manager. Processing calls GetenvContext instead of Getenv
+// with the context passed to ProcessContext, and fails with the error it
+// returns, e.g. once the context is cancelled.
+type ContextEnvironment interface {
+	Environment
+	GetenvContext(ctx context.Context, key string) (string, error)
+}
+
+// OSEnvironment is the Environment of the current process.
+type OSEnvironment struct{}
+
+func (OSEnvironment) Getenv(key string) string {
+	return os.Getenv(key)
+}
+
+func (OSEnvironment) Environ() []string {
+	return os.Environ()
+}
+
+// MapEnvironment is an Environment holding variables in a map, e.g. for
+// tests.
+type MapEnvironment map[string]string
+
+func (m MapEnvironment) Getenv(key string) string {
+	return m[key]
+}
+
+func (m MapEnvironment) Environ() []string {
+	env := make([]string, 0, len(m))
+	for k, v := range m {
+		env = append(env, k+"="+v)
+	}
+	// Sort for a stable output, map iteration order is random.
+	sort.Strings(env)
+	return env
+}
+
+// environmentChain looks variables up in several environments in order.
+type environmentChain []Environment
+
+func (c environmentChain) Getenv(key string) string {
+	for _, env := range c {
+		if value := env.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// GetenvContext passes ctx on to the environments of the chain that are
+// ContextEnvironments, and checks it before looking up the others.
+func (c environmentChain) GetenvContext(ctx context.Context, key string) (string, error) {
+	for _, env := range c {
+		var (
+			value string
+			err   error
+		)
+		if ce, ok := env.(ContextEnvironment); ok {
+			value, err = ce.GetenvContext(ctx, key)
+		} else if err = ctx.Err(); err == nil {
+			value = env.Getenv(key)
+		}
+		if value != "" || err != nil {
+			return value, err
+		}
+	}
+	return "", nil
+}
+
+func (c environmentChain) Environ() []string {
+	var env []string
+	seen := make(map[string]bool)
+	for _, e := range c {
+		for _, kv := range e.Environ() {
+			key := strings.SplitN(kv, "=", 2)[0]
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			env = append(env, kv)
+		}
+	}
+	return env
+}
@@ -1 +1,3 @@
 module github.com/reMarkable/envconfig/v2
+
+go 1.21
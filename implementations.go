@@ -0,0 +1,93 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// An ImplementationRegistry maps names to the concrete types implementing an
+// interface, to populate fields of interface type. A field of interface type
+// is populated with a new value of the type registered for it under the name
+// its variable holds, e.g. STORAGE=s3. A value containing ":" is looked up by
+// the name before it, e.g. STORAGE=s3:bucket, and if the new value implements
+// Setter, its Set method is called with the whole value.
+type ImplementationRegistry struct {
+	mu    sync.RWMutex
+	impls map[reflect.Type]map[string]reflect.Type
+}
+
+// NewImplementationRegistry returns an empty registry, to be used with
+// WithRegistry.
+func NewImplementationRegistry() *ImplementationRegistry {
+	return &ImplementationRegistry{impls: make(map[reflect.Type]map[string]reflect.Type)}
+}
+
+// defaultImplementations is the registry used unless WithRegistry is given.
+var defaultImplementations = NewImplementationRegistry()
+
+// RegisterImplementation registers the type of concrete as the implementation
+// of an interface under name, in the registry used unless WithRegistry is
+// given. iface is a nil pointer to the interface, e.g. (*Storage)(nil), and
+// concrete is a value of the implementing type, e.g. &S3Storage{}.
+func RegisterImplementation(iface interface{}, name string, concrete interface{}) error {
+	return defaultImplementations.RegisterImplementation(iface, name, concrete)
+}
+
+// RegisterImplementation registers the type of concrete as the implementation
+// of an interface under name, see the package level RegisterImplementation.
+// Registering a name twice for the same interface replaces the previous type.
+func (r *ImplementationRegistry) RegisterImplementation(iface interface{}, name string, concrete interface{}) error {
+	it := reflect.TypeOf(iface)
+	if it == nil || it.Kind() != reflect.Ptr || it.Elem().Kind() != reflect.Interface {
+		return fmt.Errorf("envconfig.RegisterImplementation: %T is not a pointer to an interface", iface)
+	}
+	it = it.Elem()
+	ct := reflect.TypeOf(concrete)
+	if ct == nil || !ct.Implements(it) {
+		return fmt.Errorf("envconfig.RegisterImplementation: %T does not implement %s", concrete, it)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.impls[it] == nil {
+		r.impls[it] = make(map[string]reflect.Type)
+	}
+	r.impls[it][name] = ct
+	return nil
+}
+
+// has reports whether any implementation of iface is registered.
+func (r *ImplementationRegistry) has(iface reflect.Type) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.impls[iface]) > 0
+}
+
+// instantiate populates field, of interface type, with a new value of the
+// implementation registered for value.
+func (r *ImplementationRegistry) instantiate(value string, field reflect.Value) error {
+	name, _, _ := strings.Cut(value, ":")
+
+	r.mu.RLock()
+	ct, ok := r.impls[field.Type()][name]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no implementation of %s registered as %q", field.Type(), name)
+	}
+
+	var impl reflect.Value
+	if ct.Kind() == reflect.Ptr {
+		impl = reflect.New(ct.Elem())
+	} else {
+		impl = reflect.New(ct).Elem()
+	}
+	if setter := setterFrom(impl); setter != nil {
+		if err := setter.Set(value); err != nil {
+			return err
+		}
+	}
+	field.Set(impl)
+	return nil
+}
@@ -0,0 +1,155 @@
+package envconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// ProcessJSON populates spec by decoding the JSON object held by the single
+// environment variable envKey, as used by platforms that group configuration
+// into one variable. Fields are decoded with encoding/json, so their names
+// follow the json struct tags, but the required, minlength, maxlength and
+// validate tags of fields that also have an envconfig tag are checked once
+// the object has been decoded. A required field counts as set if its key is
+// present in the object, even with a value like false or 0. The variable is read like those of Process,
+// so that options such as WithEnvironment and WithKeyTransformer apply, and
+// fields skipped by WithIgnoredPrefixes or WithFieldFilter are not checked.
+func ProcessJSON(envKey string, spec interface{}, opts ...Option) error {
+	o := newOptions(opts)
+	infos, err := gatherInfo("", spec, o)
+	if err != nil {
+		return err
+	}
+
+	value, err := o.getenv(envKey)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		return &RequiredError{FieldName: envKey, EnvKey: envKey}
+	}
+	if err := json.Unmarshal([]byte(value), spec); err != nil {
+		return &ParseError{
+			KeyName:   envKey,
+			FieldName: envKey,
+			TypeName:  reflect.TypeOf(spec).Elem().String(),
+			Value:     value,
+			Err:       err,
+		}
+	}
+
+	// Check required fields by the presence of their keys rather than by
+	// their values, so that an explicit false or 0 counts as set.
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value), &obj); err != nil {
+		return &ParseError{
+			KeyName:   envKey,
+			FieldName: envKey,
+			TypeName:  reflect.TypeOf(spec).Elem().String(),
+			Value:     value,
+			Err:       err,
+		}
+	}
+
+	typ := reflect.TypeOf(spec).Elem()
+	for _, info := range infos {
+		if isTrue(info.Tags.Get("required")) && !jsonHasField(obj, typ, info.Index) {
+			return &RequiredError{FieldName: info.Name, EnvKey: info.Key}
+		}
+		if err := checkJSONField(info); err != nil {
+			return &ParseError{
+				KeyName:   envKey,
+				FieldName: info.Name,
+				TypeName:  info.Field.Type().String(),
+				Value:     displayValue(info),
+				Err:       err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// jsonHasField reports whether obj holds a non-null value for the field of typ
+// given by index, following the names encoding/json decodes fields from.
+func jsonHasField(obj map[string]json.RawMessage, typ reflect.Type, index []int) bool {
+	for n, i := range index {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		f := typ.Field(i)
+		typ = f.Type
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" && f.Anonymous {
+			// The fields of embedded structs are decoded from the same object.
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		raw, ok := lookupJSONKey(obj, name)
+		if !ok || string(raw) == "null" {
+			return false
+		}
+		if n == len(index)-1 {
+			return true
+		}
+		obj = nil
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return false
+		}
+	}
+	return false
+}
+
+// lookupJSONKey returns the value of name in obj, preferring an exact match
+// but otherwise matching case-insensitively, like encoding/json.
+func lookupJSONKey(obj map[string]json.RawMessage, name string) (json.RawMessage, bool) {
+	if raw, ok := obj[name]; ok {
+		return raw, true
+	}
+	for key, raw := range obj {
+		if strings.EqualFold(key, name) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
+
+// checkJSONField checks the length limits and named validator of a field
+// decoded by ProcessJSON.
+func checkJSONField(info varInfo) error {
+	field := reflect.Indirect(info.Field)
+	if info.Tags.Get("minlength") != "" || info.Tags.Get("maxlength") != "" {
+		switch field.Kind() {
+		case reflect.String:
+			if err := checkLength(utf8.RuneCountInString(field.String()), info.Tags); err != nil {
+				return err
+			}
+		case reflect.Slice:
+			if err := checkLength(field.Len(), info.Tags); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("length limits require a string or slice, got %s", field.Type())
+		}
+	}
+
+	if name := info.Tags.Get("validate"); name != "" && !info.Field.IsZero() {
+		fn := lookupValidator(name)
+		if fn == nil {
+			return fmt.Errorf("unknown validator %q", name)
+		}
+		value, err := marshalField(info.Field, info.Tags.Get("separator"))
+		if err != nil {
+			return err
+		}
+		return fn(value)
+	}
+
+	return nil
+}
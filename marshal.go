@@ -0,0 +1,191 @@
+package envconfig
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Marshal is the inverse of Process. It returns the environment variables
+// that would reproduce the current values of the specified struct, keyed by
+// variable name. Values implementing encoding.TextMarshaler are formatted by
+// MarshalText, other values are formatted the way Process expects to read
+// them, including the separators given by the separator tag, falling back to
+// fmt.Sprint.
+func Marshal(prefix string, spec interface{}) (map[string]string, error) {
+	infos, err := gatherValues(prefix, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]string, len(infos))
+	for _, info := range infos {
+		value, err := marshalField(info.Field, info.Tags.Get("separator"))
+		if err != nil {
+			return nil, fmt.Errorf("envconfig.Marshal: formatting %s: %w", info.Key, err)
+		}
+		env[info.Key] = value
+	}
+
+	return env, nil
+}
+
+// MarshalJSON returns the variables returned by Marshal as an indented JSON
+// object, for exposing the configuration of a running service, e.g. on a debug
+// endpoint. Values of secret fields are redacted, and values implementing
+// fmt.Stringer are formatted by String, so that types such as types.RedisDSN
+// show their passwords masked.
+func MarshalJSON(prefix string, spec interface{}) ([]byte, error) {
+	infos, err := gatherValues(prefix, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]string, len(infos))
+	for _, info := range infos {
+		env[info.Key] = displayValue(info)
+	}
+
+	return json.MarshalIndent(env, "", "  ")
+}
+
+// WriteDotEnv writes the environment variables returned by Marshal to w in the
+// .env format read by Docker Compose and similar tools, in the order of the
+// fields of spec. Required fields without a value are written as commented
+// out placeholders.
+func WriteDotEnv(prefix string, spec interface{}, w io.Writer) error {
+	infos, err := gatherValues(prefix, spec)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Generated by envconfig at %s\n", time.Now().UTC().Format(time.RFC3339))
+	for _, info := range infos {
+		value, err := marshalField(info.Field, info.Tags.Get("separator"))
+		if err != nil {
+			return fmt.Errorf("envconfig.WriteDotEnv: formatting %s: %w", info.Key, err)
+		}
+		if value == "" && isTrue(info.Tags.Get("required")) {
+			fmt.Fprintf(&buf, "# %s=\n", info.Key)
+			continue
+		}
+		fmt.Fprintf(&buf, "%s=\"%s\"\n", info.Key, dotEnvEscaper.Replace(value))
+	}
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// gatherValues is like gatherInfo, but leaves nil nested structs in spec alone
+// and reads their fields as zero values, so that formatting spec does not
+// modify it.
+func gatherValues(prefix string, spec interface{}) ([]varInfo, error) {
+	typ := reflect.TypeOf(spec)
+	if typ == nil || typ.Kind() != reflect.Ptr || reflect.ValueOf(spec).IsNil() {
+		return nil, invalidSpecError(spec)
+	}
+
+	infos, err := gatherInfo(prefix, reflect.New(typ.Elem()).Interface(), newOptions(nil))
+	if err != nil {
+		return nil, err
+	}
+	s := reflect.ValueOf(spec).Elem()
+	for i := range infos {
+		infos[i].Field = lookupField(s, infos[i].Index)
+	}
+	return infos, nil
+}
+
+// dotEnvEscaper escapes a value for a double-quoted .env value.
+var dotEnvEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+)
+
+// marshalField formats field the way processField reads it. seps holds the
+// separators to use for nested slices and maps, as for processField.
+func marshalField(field reflect.Value, seps string) (string, error) {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return "", nil
+		}
+		field = field.Elem()
+	}
+
+	if t := textMarshaler(field); t != nil {
+		b, err := t.MarshalText()
+		return string(b), err
+	}
+
+	// Process passes values to UnmarshalBinary as they are, so the same holds
+	// the other way around. This covers url.URL.
+	if b := binaryMarshaler(field); b != nil {
+		data, err := b.MarshalBinary()
+		return string(data), err
+	}
+
+	switch field.Kind() {
+	case reflect.Slice:
+		if field.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(field.Bytes()), nil
+		}
+		sep, rest := nextSeparator(seps, ",")
+		vals := make([]string, field.Len())
+		for i := range vals {
+			val, err := marshalField(field.Index(i), rest)
+			if err != nil {
+				return "", err
+			}
+			vals[i] = val
+		}
+		return strings.Join(vals, sep), nil
+	case reflect.Map:
+		sep, rest := nextSeparator(seps, ";")
+		pairs := make([]string, 0, field.Len())
+		iter := field.MapRange()
+		for iter.Next() {
+			k, err := marshalField(iter.Key(), "")
+			if err != nil {
+				return "", err
+			}
+			v, err := marshalField(iter.Value(), rest)
+			if err != nil {
+				return "", err
+			}
+			pairs = append(pairs, k+":"+v)
+		}
+		// Sort for a stable output, map iteration order is random.
+		sort.Strings(pairs)
+		return strings.Join(pairs, sep), nil
+	}
+
+	if !field.CanInterface() {
+		return "", nil
+	}
+	return fmt.Sprint(field.Interface()), nil
+}
+
+func textMarshaler(field reflect.Value) (t encoding.TextMarshaler) {
+	interfaceFrom(field, func(v interface{}, ok *bool) { t, *ok = v.(encoding.TextMarshaler) })
+	return t
+}
+
+func binaryMarshaler(field reflect.Value) (b encoding.BinaryMarshaler) {
+	interfaceFrom(field, func(v interface{}, ok *bool) { b, *ok = v.(encoding.BinaryMarshaler) })
+	return b
+}
+
+func stringer(field reflect.Value) (s fmt.Stringer) {
+	interfaceFrom(field, func(v interface{}, ok *bool) { s, *ok = v.(fmt.Stringer) })
+	return s
+}
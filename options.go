@@ -0,0 +1,318 @@
+package envconfig
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// An Option configures how a specification is processed.
+type Option func(*options)
+
+// options holds the settings assembled from a list of Option values.
+type options struct {
+	separator       string
+	ignoredPrefixes []string
+	strict          bool
+	validate        bool
+	logger          *slog.Logger
+	requireNonEmpty bool
+	prefix          *string
+	tagNames        map[string]string
+	strictDuration  bool
+	weakBool        bool
+	fieldFilters    []func(fieldName, envKey string) bool
+	missingHandler  func(envKey, fieldName, defaultValue string)
+	requireAll      bool
+	keyTransformer  func(key string) string
+	env             Environment
+	auditLog        func(envKey, source, value string)
+	implementations *ImplementationRegistry
+	schemaVersion   *string
+
+	// ctx, partial, reload and dryRun are set by the Process variants, not by
+	// options.
+	ctx     context.Context
+	partial bool
+	reload  bool
+	dryRun  bool
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		separator:       "_",
+		env:             OSEnvironment{},
+		implementations: defaultImplementations,
+		ctx:             context.Background(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithStrictMode makes processing fail if any environment variable with the
+// prefix is unknown to the specification, as reported by CheckDisallowed.
+func WithStrictMode(strict bool) Option {
+	return func(o *options) {
+		o.strict = strict
+	}
+}
+
+// WithValidation makes processing call the Validate method of specifications
+// implementing Validator once all fields have been populated.
+func WithValidation(validate bool) Option {
+	return func(o *options) {
+		o.validate = validate
+	}
+}
+
+// WithLogger makes processing log the resolved configuration to logger as a
+// single entry once all fields have been populated. The values of fields
+// tagged `secret:"true"` are redacted, and values of types that mask their
+// credentials themselves, such as types.BearerToken and types.RedisDSN, are
+// logged masked by their Masked or String methods.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithPrefixSeparator replaces the "_" used to join the prefix, the names of
+// nested structs and the names of fields into environment variable names. An
+// empty separator concatenates them.
+func WithPrefixSeparator(sep string) Option {
+	return func(o *options) {
+		o.separator = sep
+	}
+}
+
+// WithPrefixOverride replaces the prefix passed to ProcessWithOptions or
+// CheckDisallowed, so that the prefix can be chosen where the options are
+// assembled rather than where the specification is processed.
+func WithPrefixOverride(prefix string) Option {
+	return func(o *options) {
+		o.prefix = &prefix
+	}
+}
+
+// WithTagName makes processing read the struct tag normally called purpose,
+// such as "envconfig", "default" or "required", from tagName instead. The
+// default name is no longer recognized, which eases migrating from libraries
+// that use tags like env:"HOST".
+func WithTagName(purpose, tagName string) Option {
+	return func(o *options) {
+		if o.tagNames == nil {
+			o.tagNames = make(map[string]string)
+		}
+		o.tagNames[purpose] = tagName
+	}
+}
+
+// WithStrictDuration makes time.Duration fields reject the "d" (day) suffix
+// envconfig accepts in addition to the units of time.ParseDuration.
+func WithStrictDuration(strict bool) Option {
+	return func(o *options) {
+		o.strictDuration = strict
+	}
+}
+
+// WithSchemaVersion makes processing fail with a SchemaVersionMismatchError
+// unless the field tagged `envconfig:"SCHEMA_VERSION"` at the top level of the
+// specification holds expected, such as when a deployment still provides the
+// variables of an older version of the specification. Specifications without
+// such a field are not checked.
+func WithSchemaVersion(expected string) Option {
+	return func(o *options) {
+		o.schemaVersion = &expected
+	}
+}
+
+// WithWeakBoolParsing makes bool fields accept "yes", "no", "on" and "off", in
+// any case, in addition to the values accepted by strconv.ParseBool.
+func WithWeakBoolParsing(weak bool) Option {
+	return func(o *options) {
+		o.weakBool = weak
+	}
+}
+
+// WithRequireNonEmpty makes required fields fail with a RequiredError when
+// their value is only whitespace, or when the populated field is still empty,
+// such as a string read from an empty file with the file tag.
+func WithRequireNonEmpty(require bool) Option {
+	return func(o *options) {
+		o.requireNonEmpty = require
+	}
+}
+
+// WithIgnoredPrefixes skips every field whose environment variable name is one
+// of the prefixes or starts with it followed by the separator, as if it were
+// tagged `ignored:"true"`. Variables
+// with these prefixes are not reported by CheckDisallowed either, which makes
+// it possible to leave entire sub-trees to another configuration mechanism.
+func WithIgnoredPrefixes(prefixes ...string) Option {
+	return func(o *options) {
+		for _, prefix := range prefixes {
+			o.ignoredPrefixes = append(o.ignoredPrefixes, strings.ToUpper(prefix))
+		}
+	}
+}
+
+// envKey returns the name key is looked up by in the environment.
+func (o *options) envKey(key string) string {
+	if o.keyTransformer != nil {
+		return o.keyTransformer(key)
+	}
+	return key
+}
+
+// getenv returns the value of the environment variable for key, passing the
+// context of processing on to a ContextEnvironment.
+func (o *options) getenv(key string) (string, error) {
+	if env, ok := o.env.(ContextEnvironment); ok {
+		return env.GetenvContext(o.ctx, o.envKey(key))
+	}
+	return o.env.Getenv(o.envKey(key)), nil
+}
+
+// isIgnoredEnvKey is like isIgnoredKey, for names already transformed by
+// envKey.
+func (o *options) isIgnoredEnvKey(name string) bool {
+	for _, prefix := range o.ignoredPrefixes {
+		if o.hasIgnoredPrefix(name, prefix, o.envKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// audit reports the source of the value of a field to the WithAuditLog
+// function, if any.
+func (o *options) audit(info varInfo, source, value string) {
+	if o.auditLog == nil {
+		return
+	}
+	if value != "" && isTrue(info.Tags.Get("secret")) {
+		value = redacted
+	}
+	o.auditLog(info.Key, source, value)
+}
+
+// resolvePrefix returns the prefix to use in place of prefix.
+func (o *options) resolvePrefix(prefix string) string {
+	if o.prefix != nil {
+		return *o.prefix
+	}
+	return prefix
+}
+
+// WithRequireAllOrDefault makes every field required unless it has a default,
+// for deployments where a zero value is never intended. Ignored fields are
+// exempt.
+func WithRequireAllOrDefault(require bool) Option {
+	return func(o *options) {
+		o.requireAll = require
+	}
+}
+
+// WithKeyTransformer makes processing look up every environment variable by
+// the name transform returns for it, for environments that name variables
+// differently, such as "app.port" instead of "APP_PORT". CheckDisallowed
+// compares the variables it finds with the transformed names as well.
+func WithKeyTransformer(transform func(key string) string) Option {
+	return func(o *options) {
+		o.keyTransformer = transform
+	}
+}
+
+// WithEnvironment makes processing read variables from env instead of the
+// environment of the process.
+func WithEnvironment(env Environment) Option {
+	return func(o *options) {
+		o.env = env
+	}
+}
+
+// WithEnvironmentChain makes processing read each variable from the first of
+// envs in which it is set to a non-empty value. Listing the variables, as
+// CheckDisallowed does, returns those of all envs, taking the value of a
+// variable set in several from the first of them. Include OSEnvironment in
+// envs to fall back to the environment of the process.
+func WithEnvironmentChain(envs ...Environment) Option {
+	return func(o *options) {
+		o.env = environmentChain(envs)
+	}
+}
+
+// WithRegistry makes processing populate fields of interface type with the
+// implementations registered in r, instead of those registered with the
+// package level RegisterImplementation.
+func WithRegistry(r *ImplementationRegistry) Option {
+	return func(o *options) {
+		o.implementations = r
+	}
+}
+
+// The sources of a value reported by WithAuditLog.
+const (
+	sourceEnv     = "env"
+	sourceDefault = "default"
+	sourceZero    = "zero"
+)
+
+// WithAuditLog calls fn for every field once it has been populated, with the
+// source of its value: "env" for the environment, "default" for its default
+// or "zero" when it has neither, and the raw value used. The values of fields
+// tagged `secret:"true"` are redacted.
+func WithAuditLog(fn func(envKey, source, value string)) Option {
+	return func(o *options) {
+		o.auditLog = fn
+	}
+}
+
+// WithMissingHandler calls handler for every field that is not required and
+// whose environment variable is not set, before the field falls back to its
+// default. defaultValue is empty if the field has no default and is left at
+// its zero value.
+func WithMissingHandler(handler func(envKey, fieldName, defaultValue string)) Option {
+	return func(o *options) {
+		o.missingHandler = handler
+	}
+}
+
+// WithFieldFilter skips every field for which skip returns true, as if it were
+// tagged `ignored:"true"`. skip is called with the name of the struct field
+// and its environment variable name. Since skipped fields are unknown to the
+// specification, CheckDisallowed reports their variables if they are set.
+func WithFieldFilter(skip func(fieldName, envKey string) bool) Option {
+	return func(o *options) {
+		o.fieldFilters = append(o.fieldFilters, skip)
+	}
+}
+
+// isFiltered reports whether a field is skipped by WithFieldFilter.
+func (o *options) isFiltered(fieldName, envKey string) bool {
+	for _, skip := range o.fieldFilters {
+		if skip(fieldName, envKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *options) isIgnoredKey(key string) bool {
+	for _, prefix := range o.ignoredPrefixes {
+		if o.hasIgnoredPrefix(key, prefix, func(k string) string { return k }) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasIgnoredPrefix reports whether key is prefix, or starts with prefix
+// followed by the separator, so that "APP_DB" does not match "APP_DBX_HOST".
+// Both are compared as named by transform.
+func (o *options) hasIgnoredPrefix(key, prefix string, transform func(string) string) bool {
+	prefix = strings.TrimSuffix(prefix, o.separator)
+	return key == transform(prefix) || strings.HasPrefix(key, transform(prefix+o.separator))
+}
@@ -0,0 +1,78 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// namedSpec is a specification registered with Register.
+type namedSpec struct {
+	name   string
+	prefix string
+	spec   interface{}
+}
+
+var (
+	namedSpecsMu sync.RWMutex
+	namedSpecs   []namedSpec
+)
+
+// Register adds spec to a global registry of configurations under name, to be
+// populated with prefix by ProcessRegistry. It lets library packages declare
+// their own configuration, which the main package then processes all at once.
+// Registering a name twice is an error.
+func Register(name, prefix string, spec interface{}) error {
+	s := reflect.ValueOf(spec)
+	if s.Kind() != reflect.Ptr || s.Elem().Kind() != reflect.Struct {
+		return invalidSpecError(spec)
+	}
+
+	namedSpecsMu.Lock()
+	defer namedSpecsMu.Unlock()
+	for _, n := range namedSpecs {
+		if n.name == name {
+			return fmt.Errorf("envconfig.Register: configuration %q already registered", name)
+		}
+	}
+	namedSpecs = append(namedSpecs, namedSpec{name: name, prefix: prefix, spec: spec})
+	return nil
+}
+
+// resetRegistry removes every registered specification, for tests.
+func resetRegistry() {
+	namedSpecsMu.Lock()
+	defer namedSpecsMu.Unlock()
+	namedSpecs = nil
+}
+
+// Get returns the specification registered under name, or nil if there is
+// none.
+func Get(name string) interface{} {
+	namedSpecsMu.RLock()
+	defer namedSpecsMu.RUnlock()
+	for _, n := range namedSpecs {
+		if n.name == name {
+			return n.spec
+		}
+	}
+	return nil
+}
+
+// ProcessRegistry processes every registered specification with its prefix,
+// in the order they were registered. All specifications are processed even if
+// some fail, and their errors are combined with errors.Join.
+func ProcessRegistry(opts ...Option) error {
+	namedSpecsMu.RLock()
+	specs := append([]namedSpec(nil), namedSpecs...)
+	namedSpecsMu.RUnlock()
+
+	var errs []error
+	for _, n := range specs {
+		if err := ProcessWithOptions(n.prefix, n.spec, opts...); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,96 @@
+package envconfig
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// renameTags rewrites tag so that the struct tags renamed by WithTagName are
+// available under their default names, and the default names they replace are
+// dropped. names maps a default tag name to the name used instead.
+func renameTags(tag reflect.StructTag, names map[string]string) reflect.StructTag {
+	if len(names) == 0 {
+		return tag
+	}
+
+	purposes := make(map[string]string, len(names))
+	for purpose, name := range names {
+		purposes[name] = purpose
+	}
+
+	var b strings.Builder
+	for _, kv := range splitTag(tag) {
+		key := kv[0]
+		if purpose, ok := purposes[key]; ok {
+			key = purpose
+		} else if _, ok := names[key]; ok {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(key + ":" + kv[1])
+	}
+	return reflect.StructTag(b.String())
+}
+
+// splitTag splits a conventional struct tag into its keys and quoted values,
+// following the same rules as reflect.StructTag.Lookup.
+func splitTag(tag reflect.StructTag) [][2]string {
+	var kvs [][2]string
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := string(tag[:i])
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		value := string(tag[:i+1])
+		tag = tag[i+1:]
+
+		if _, err := strconv.Unquote(value); err != nil {
+			break
+		}
+		kvs = append(kvs, [2]string{key, value})
+	}
+	return kvs
+}
+
+// tagNamesKey returns a comparable representation of names for use in the
+// field cache key.
+func tagNamesKey(names map[string]string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(names))
+	for purpose, name := range names {
+		pairs = append(pairs, purpose+"="+name)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
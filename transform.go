@@ -0,0 +1,48 @@
+package envconfig
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	transformsMu sync.RWMutex
+	transforms   = map[string]func(value string) (string, error){
+		"upper": func(value string) (string, error) {
+			return strings.ToUpper(value), nil
+		},
+		"lower": func(value string) (string, error) {
+			return strings.ToLower(value), nil
+		},
+		"base64decode": func(value string) (string, error) {
+			b, err := base64.StdEncoding.DecodeString(value)
+			return string(b), err
+		},
+	}
+)
+
+// RegisterTransform makes a transformation available by name to fields
+// tagged with `transform:"name"`. The function is called with the raw value
+// before it is converted to the field type. The built-in transforms are
+// "upper", "lower" and "base64decode"; registering a name twice replaces the
+// previous function.
+func RegisterTransform(name string, fn func(value string) string) {
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+	transforms[name] = func(value string) (string, error) {
+		return fn(value), nil
+	}
+}
+
+// applyTransform runs the named transform on value.
+func applyTransform(value, name string) (string, error) {
+	transformsMu.RLock()
+	fn := transforms[name]
+	transformsMu.RUnlock()
+	if fn == nil {
+		return "", fmt.Errorf("unknown transform %q", name)
+	}
+	return fn(value)
+}
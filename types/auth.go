@@ -0,0 +1,185 @@
+package types
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// BASIC AUTH CREDENTIALS
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidBasicAuthCredentials means the configured value is not a "user:password" pair.
+	ErrInvalidBasicAuthCredentials = errors.New("basic auth credentials are not valid format")
+)
+
+// BasicAuthCredentials is a "user:password" pair. The password may itself
+// contain colons.
+type BasicAuthCredentials struct {
+	Username string
+	Password string
+}
+
+func (c *BasicAuthCredentials) Set(value string) error {
+	i := strings.Index(value, ":")
+	if i <= 0 || i == len(value)-1 {
+		return ErrInvalidBasicAuthCredentials
+	}
+
+	c.Username = value[:i]
+	c.Password = value[i+1:]
+
+	return nil
+}
+
+// Masked returns the credentials with the password replaced by "***", for
+// logging.
+func (c BasicAuthCredentials) Masked() string {
+	return c.Username + ":***"
+}
+
+// String returns the credentials masked, like Masked, so that they are safe to
+// print. MarshalText returns them in full.
+func (c BasicAuthCredentials) String() string {
+	return c.Masked()
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the credentials
+// including the password in the format Set accepts, or nothing for the zero
+// value.
+func (c BasicAuthCredentials) MarshalText() ([]byte, error) {
+	if c == (BasicAuthCredentials{}) {
+		return nil, nil
+	}
+	return []byte(c.Username + ":" + c.Password), nil
+}
+
+// AuthorizationHeader returns the value of an HTTP Authorization header using
+// the Basic scheme.
+func (c BasicAuthCredentials) AuthorizationHeader() string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.Username+":"+c.Password))
+}
+
+// -----------------------------------------------------------------------------
+// BEARER TOKEN
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrEmptyBearerToken means the configured value holds no token.
+	ErrEmptyBearerToken = errors.New("bearer token is empty")
+)
+
+// BearerToken is a token used with the Bearer scheme of the HTTP Authorization
+// header. The value may be configured with or without a leading "Bearer ".
+type BearerToken string
+
+func (t *BearerToken) Set(value string) error {
+	value = strings.TrimSpace(value)
+	if len(value) > 7 && strings.EqualFold(value[:7], "Bearer ") {
+		value = strings.TrimSpace(value[7:])
+	}
+	if value == "" || strings.EqualFold(value, "Bearer") {
+		return ErrEmptyBearerToken
+	}
+
+	*t = BearerToken(value)
+
+	return nil
+}
+
+// AuthorizationHeader returns the value of an HTTP Authorization header using
+// the Bearer scheme.
+func (t BearerToken) AuthorizationHeader() string {
+	return "Bearer " + string(t)
+}
+
+// Masked returns the first four characters of the token followed by "***",
+// for logging. Tokens of eight characters or less are masked entirely.
+func (t BearerToken) Masked() string {
+	if len(t) <= 8 {
+		return "***"
+	}
+	return string(t[:4]) + "***"
+}
+
+// -----------------------------------------------------------------------------
+// OAUTH SCOPE
+// -----------------------------------------------------------------------------
+
+// OAuthScope is a list of OAuth scopes separated by whitespace, such as
+// "openid email profile", as is conventional for OAuth.
+type OAuthScope []string
+
+func (s *OAuthScope) Set(value string) error {
+	*s = strings.Fields(value)
+	return nil
+}
+
+// Join returns the scopes separated by single spaces.
+func (s OAuthScope) Join() string {
+	return strings.Join(s, " ")
+}
+
+func (s OAuthScope) String() string {
+	return s.Join()
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the scopes
+// separated by spaces, as Set reads them.
+func (s OAuthScope) MarshalText() ([]byte, error) {
+	return []byte(s.Join()), nil
+}
+
+// -----------------------------------------------------------------------------
+// JWT AUDIENCE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidJWTAudience means the configured value holds no audience, or an
+	// audience that is neither a plain string nor a valid URI.
+	ErrInvalidJWTAudience = errors.New("jwt audience is not valid")
+)
+
+// JWTAudience is the list of audiences accepted in the aud claim of a JWT,
+// such as "api" or "https://api.example.com https://admin.example.com",
+// separated by whitespace. As for the StringOrURI type of RFC 7519, an
+// audience containing ":" must be a URI.
+type JWTAudience []string
+
+func (a *JWTAudience) Set(value string) error {
+	auds := strings.Fields(value)
+	if len(auds) == 0 {
+		return ErrInvalidJWTAudience
+	}
+	for _, aud := range auds {
+		if !strings.Contains(aud, ":") {
+			continue
+		}
+		if u, err := url.Parse(aud); err != nil || u.Scheme == "" {
+			return ErrInvalidJWTAudience
+		}
+	}
+
+	*a = auds
+
+	return nil
+}
+
+// Contains reports whether aud is one of the audiences.
+func (a JWTAudience) Contains(aud string) bool {
+	return slices.Contains(a, aud)
+}
+
+func (a JWTAudience) String() string {
+	return strings.Join(a, " ")
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the audiences
+// separated by spaces, as Set reads them.
+func (a JWTAudience) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"errors"
+	"regexp"
+)
+
+// -----------------------------------------------------------------------------
+// AWS REGION
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidAWSRegion means the configured value is not an AWS region name.
+	ErrInvalidAWSRegion = errors.New("aws region is not valid format")
+
+	// Regions are a geography, one or two name parts and a number, such as
+	// "eu-west-2" or "us-gov-west-1".
+	awsRegionRegexp = regexp.MustCompile(`^[a-z]{2}(-[a-z]+){1,2}-\d+$`)
+)
+
+// AWSRegion is an AWS region name such as "us-east-1".
+type AWSRegion string
+
+func (r *AWSRegion) Set(value string) error {
+	if !awsRegionRegexp.MatchString(value) {
+		return ErrInvalidAWSRegion
+	}
+
+	*r = AWSRegion(value)
+
+	return nil
+}
+
+func (r AWSRegion) String() string {
+	return string(r)
+}
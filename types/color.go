@@ -0,0 +1,83 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// HEX COLOR
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidHexColor means the configured value is not a hex color code.
+	ErrInvalidHexColor = errors.New("hex color is not valid format")
+)
+
+// HexColor is a CSS color code in the form "#RRGGBB", the shorthand "#RGB",
+// or "#RRGGBBAA" with an alpha channel.
+type HexColor struct {
+	rgb      [3]byte
+	alpha    byte
+	hasAlpha bool
+}
+
+func (c *HexColor) Set(value string) error {
+	if !strings.HasPrefix(value, "#") {
+		return ErrInvalidHexColor
+	}
+	digits := value[1:]
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	if len(digits) != 6 && len(digits) != 8 {
+		return ErrInvalidHexColor
+	}
+
+	b, err := hex.DecodeString(digits)
+	if err != nil {
+		return ErrInvalidHexColor
+	}
+
+	*c = HexColor{}
+	copy(c.rgb[:], b)
+	if len(b) == 4 {
+		c.alpha = b[3]
+		c.hasAlpha = true
+	}
+
+	return nil
+}
+
+// R returns the red component.
+func (c HexColor) R() byte { return c.rgb[0] }
+
+// G returns the green component.
+func (c HexColor) G() byte { return c.rgb[1] }
+
+// B returns the blue component.
+func (c HexColor) B() byte { return c.rgb[2] }
+
+// A returns the alpha component, which is 255 (opaque) unless set.
+func (c HexColor) A() byte {
+	if !c.hasAlpha {
+		return 255
+	}
+	return c.alpha
+}
+
+// HasAlpha reports whether the color was given with an alpha channel.
+func (c HexColor) HasAlpha() bool {
+	return c.hasAlpha
+}
+
+// String returns the color as "#RRGGBB", or "#RRGGBBAA" if it has an alpha
+// channel, in uppercase.
+func (c HexColor) String() string {
+	b := c.rgb[:]
+	if c.hasAlpha {
+		b = append(b[:3:3], c.alpha)
+	}
+	return "#" + strings.ToUpper(hex.EncodeToString(b))
+}
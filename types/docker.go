@@ -0,0 +1,95 @@
+package types
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// DOCKER IMAGE REFERENCE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidDockerImageReference means the configured image reference has the wrong format.
+	ErrInvalidDockerImageReference = errors.New("docker image reference is not valid format")
+
+	dockerRegistryRegexp  = regexp.MustCompile(`^(localhost|[a-zA-Z0-9-]+(\.[a-zA-Z0-9-]+)+)(:[0-9]+)?$|^[a-zA-Z0-9-]+:[0-9]+$`)
+	dockerComponentRegexp = regexp.MustCompile(`^[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*$`)
+	dockerTagRegexp       = regexp.MustCompile(`^\w[\w.-]{0,127}$`)
+	dockerDigestRegexp    = regexp.MustCompile(`^[a-z0-9]+:[a-f0-9]{32,}$`)
+)
+
+// DockerImageReference is a reference to a Docker image such as "ubuntu:22.04"
+// or "gcr.io/my-project/my-image:v1.2.3". Repository holds the path between
+// the registry and the image name, if any. The tag defaults to "latest" when
+// neither a tag nor a digest is given.
+type DockerImageReference struct {
+	Registry   string
+	Repository string
+	Name       string
+	Tag        string
+	Digest     string
+}
+
+func (ref *DockerImageReference) Set(value string) error {
+	var r DockerImageReference
+
+	if i := strings.Index(value, "@"); i >= 0 {
+		r.Digest = value[i+1:]
+		value = value[:i]
+		if !dockerDigestRegexp.MatchString(r.Digest) {
+			return ErrInvalidDockerImageReference
+		}
+	}
+
+	// A colon after the last slash separates the tag, any other colon belongs
+	// to the registry port.
+	if i := strings.LastIndex(value, ":"); i > strings.LastIndex(value, "/") {
+		r.Tag = value[i+1:]
+		value = value[:i]
+		if !dockerTagRegexp.MatchString(r.Tag) {
+			return ErrInvalidDockerImageReference
+		}
+	} else if r.Digest == "" {
+		r.Tag = "latest"
+	}
+
+	// Like Docker, take the first component for a registry if it is
+	// "localhost", or holds a "." or a port.
+	components := strings.Split(value, "/")
+	if len(components) > 1 && dockerRegistryRegexp.MatchString(components[0]) {
+		r.Registry = components[0]
+		components = components[1:]
+	}
+	for _, c := range components {
+		if !dockerComponentRegexp.MatchString(c) {
+			return ErrInvalidDockerImageReference
+		}
+	}
+
+	r.Name = components[len(components)-1]
+	r.Repository = strings.Join(components[:len(components)-1], "/")
+
+	*ref = r
+
+	return nil
+}
+
+func (ref DockerImageReference) String() string {
+	var sb strings.Builder
+	if ref.Registry != "" {
+		sb.WriteString(ref.Registry + "/")
+	}
+	if ref.Repository != "" {
+		sb.WriteString(ref.Repository + "/")
+	}
+	sb.WriteString(ref.Name)
+	if ref.Tag != "" {
+		sb.WriteString(":" + ref.Tag)
+	}
+	if ref.Digest != "" {
+		sb.WriteString("@" + ref.Digest)
+	}
+	return sb.String()
+}
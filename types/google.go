@@ -2,6 +2,7 @@ package types
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 )
 
@@ -13,7 +14,10 @@ var (
 	// ErrInvalidGoogleTopicID means the configured topic has the wrong format.
 	ErrInvalidGoogleTopicID = errors.New("topic is not valid format")
 
-	googleTopicRegexp = regexp.MustCompile(`projects\/([\w-]+)\/topics\/([\w-]+)`)
+	// Topic IDs start with a letter and may contain dots, tildes, percent and
+	// plus signs. Project IDs may start with a digit in older projects, and
+	// domain-scoped projects contain a dot and a colon.
+	googleTopicRegexp = regexp.MustCompile(`^projects/([a-zA-Z0-9][\w.:-]*)/topics/([a-zA-Z][\w.~%+-]*)$`)
 )
 
 type GooglePubSubTopic struct {
@@ -33,6 +37,18 @@ func (pst *GooglePubSubTopic) Set(value string) error {
 	return nil
 }
 
+func (pst GooglePubSubTopic) String() string {
+	if pst == (GooglePubSubTopic{}) {
+		return ""
+	}
+	return fmt.Sprintf("projects/%s/topics/%s", pst.ProjectID, pst.TopicID)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (pst GooglePubSubTopic) MarshalText() ([]byte, error) {
+	return []byte(pst.String()), nil
+}
+
 // -----------------------------------------------------------------------------
 // FIRESTORE DATABASE
 // -----------------------------------------------------------------------------
@@ -41,22 +57,559 @@ var (
 	// ErrInvalidGoogleFirestoreID means the configured database id has the wrong format.
 	ErrInvalidGoogleFirestoreID = errors.New("firestore id is not valid format")
 
-	googleFirestoreRegexp = regexp.MustCompile(`projects\/([\w-]+)\/databases\/([\w-]+|\(default\))`)
+	googleFirestoreRegexp = regexp.MustCompile(`^(projects/)?([\w-]+)/databases/([\w-]+|\(default\))$`)
 )
 
+// GoogleFirestoreDatabase accepts "projects/p/databases/d", or the short form
+// "p/databases/d" used with the Firestore emulator, which sets Emulator.
 type GoogleFirestoreDatabase struct {
 	ProjectID string
 	Database  string
+	Emulator  bool
 }
 
 func (pst *GoogleFirestoreDatabase) Set(value string) error {
 	m := googleFirestoreRegexp.FindStringSubmatch(value)
-	if len(m) != 3 {
+	if len(m) != 4 {
 		return ErrInvalidGoogleFirestoreID
 	}
 
-	pst.ProjectID = m[1]
-	pst.Database = m[2]
+	pst.ProjectID = m[2]
+	pst.Database = m[3]
+	pst.Emulator = m[1] == ""
 
 	return nil
 }
+
+// DatabaseID returns the ID of the database, such as "(default)", which is
+// what most Firestore client calls expect.
+func (pst GoogleFirestoreDatabase) DatabaseID() string {
+	return pst.Database
+}
+
+// FullPath returns the resource name "projects/p/databases/d", also for the
+// short emulator form.
+func (pst GoogleFirestoreDatabase) FullPath() string {
+	return fmt.Sprintf("projects/%s/databases/%s", pst.ProjectID, pst.Database)
+}
+
+func (pst GoogleFirestoreDatabase) String() string {
+	if pst == (GoogleFirestoreDatabase{}) {
+		return ""
+	}
+	if pst.Emulator {
+		return fmt.Sprintf("%s/databases/%s", pst.ProjectID, pst.Database)
+	}
+	return pst.FullPath()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (pst GoogleFirestoreDatabase) MarshalText() ([]byte, error) {
+	return []byte(pst.String()), nil
+}
+
+// -----------------------------------------------------------------------------
+// CLOUD RUN SERVICE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidGoogleCloudRunService means the configured service has the wrong format.
+	ErrInvalidGoogleCloudRunService = errors.New("cloud run service is not valid format")
+
+	googleCloudRunServiceRegexp = regexp.MustCompile(`^projects/([\w-]+)/locations/([a-z][a-z0-9-]*)/services/([\w-]+)$`)
+)
+
+type GoogleCloudRunService struct {
+	ProjectID string
+	Location  string
+	ServiceID string
+}
+
+func (crs *GoogleCloudRunService) Set(value string) error {
+	m := googleCloudRunServiceRegexp.FindStringSubmatch(value)
+	if len(m) != 4 {
+		return ErrInvalidGoogleCloudRunService
+	}
+
+	crs.ProjectID = m[1]
+	crs.Location = m[2]
+	crs.ServiceID = m[3]
+
+	return nil
+}
+
+func (crs GoogleCloudRunService) String() string {
+	if crs == (GoogleCloudRunService{}) {
+		return ""
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/services/%s", crs.ProjectID, crs.Location, crs.ServiceID)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (crs GoogleCloudRunService) MarshalText() ([]byte, error) {
+	return []byte(crs.String()), nil
+}
+
+// -----------------------------------------------------------------------------
+// KMS KEY
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidGoogleKMSKey means the configured key has the wrong format.
+	ErrInvalidGoogleKMSKey = errors.New("kms key is not valid format")
+
+	googleKMSKeyRegexp = regexp.MustCompile(`^projects/([\w-]+)/locations/([a-z][a-z0-9-]*)/keyRings/([\w-]+)/cryptoKeys/([\w-]+)$`)
+)
+
+type GoogleKMSKey struct {
+	ProjectID string
+	Location  string
+	KeyRing   string
+	CryptoKey string
+}
+
+func (k *GoogleKMSKey) Set(value string) error {
+	m := googleKMSKeyRegexp.FindStringSubmatch(value)
+	if len(m) != 5 {
+		return ErrInvalidGoogleKMSKey
+	}
+
+	k.ProjectID = m[1]
+	k.Location = m[2]
+	k.KeyRing = m[3]
+	k.CryptoKey = m[4]
+
+	return nil
+}
+
+func (k GoogleKMSKey) String() string {
+	if k == (GoogleKMSKey{}) {
+		return ""
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/keyRings/%s/cryptoKeys/%s", k.ProjectID, k.Location, k.KeyRing, k.CryptoKey)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k GoogleKMSKey) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// -----------------------------------------------------------------------------
+// BIGTABLE INSTANCE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidGoogleBigtableInstance means the configured instance has the wrong format.
+	ErrInvalidGoogleBigtableInstance = errors.New("bigtable instance is not valid format")
+
+	googleBigtableInstanceRegexp = regexp.MustCompile(`^projects/([\w-]+)/instances/([\w-]+)$`)
+)
+
+type GoogleBigtableInstance struct {
+	ProjectID  string
+	InstanceID string
+}
+
+func (bi *GoogleBigtableInstance) Set(value string) error {
+	m := googleBigtableInstanceRegexp.FindStringSubmatch(value)
+	if len(m) != 3 {
+		return ErrInvalidGoogleBigtableInstance
+	}
+
+	bi.ProjectID = m[1]
+	bi.InstanceID = m[2]
+
+	return nil
+}
+
+func (bi GoogleBigtableInstance) String() string {
+	if bi == (GoogleBigtableInstance{}) {
+		return ""
+	}
+	return fmt.Sprintf("projects/%s/instances/%s", bi.ProjectID, bi.InstanceID)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (bi GoogleBigtableInstance) MarshalText() ([]byte, error) {
+	return []byte(bi.String()), nil
+}
+
+// -----------------------------------------------------------------------------
+// BIGTABLE TABLE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidGoogleBigtableTable means the configured table has the wrong format.
+	ErrInvalidGoogleBigtableTable = errors.New("bigtable table is not valid format")
+
+	googleBigtableTableRegexp = regexp.MustCompile(`^projects/([\w-]+)/instances/([\w-]+)/tables/([\w.-]+)$`)
+)
+
+type GoogleBigtableTable struct {
+	ProjectID  string
+	InstanceID string
+	TableID    string
+}
+
+func (bt *GoogleBigtableTable) Set(value string) error {
+	m := googleBigtableTableRegexp.FindStringSubmatch(value)
+	if len(m) != 4 {
+		return ErrInvalidGoogleBigtableTable
+	}
+
+	bt.ProjectID = m[1]
+	bt.InstanceID = m[2]
+	bt.TableID = m[3]
+
+	return nil
+}
+
+func (bt GoogleBigtableTable) String() string {
+	if bt == (GoogleBigtableTable{}) {
+		return ""
+	}
+	return fmt.Sprintf("projects/%s/instances/%s/tables/%s", bt.ProjectID, bt.InstanceID, bt.TableID)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (bt GoogleBigtableTable) MarshalText() ([]byte, error) {
+	return []byte(bt.String()), nil
+}
+
+// -----------------------------------------------------------------------------
+// SERVICE ACCOUNT EMAIL
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidGoogleServiceAccountEmail means the configured email is not a service account.
+	ErrInvalidGoogleServiceAccountEmail = errors.New("service account email is not valid format")
+
+	googleServiceAccountEmailRegexp  = regexp.MustCompile(`^([a-z0-9][a-z0-9-]*[a-z0-9])@(?:([a-z][a-z0-9-]*[a-z0-9])\.iam|developer)\.gserviceaccount\.com$`)
+	googleLegacyServiceAccountRegexp = regexp.MustCompile(`^(\d+)-`)
+)
+
+// GoogleServiceAccountEmail is a service account email such as
+// "name@project.iam.gserviceaccount.com", or the legacy
+// "123456789-compute@developer.gserviceaccount.com" used by older projects.
+type GoogleServiceAccountEmail string
+
+func (e *GoogleServiceAccountEmail) Set(value string) error {
+	if !googleServiceAccountEmailRegexp.MatchString(value) {
+		return ErrInvalidGoogleServiceAccountEmail
+	}
+
+	*e = GoogleServiceAccountEmail(value)
+
+	return nil
+}
+
+func (e GoogleServiceAccountEmail) String() string {
+	return string(e)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (e GoogleServiceAccountEmail) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// Name returns the part of the email before the "@".
+func (e GoogleServiceAccountEmail) Name() string {
+	m := googleServiceAccountEmailRegexp.FindStringSubmatch(string(e))
+	if len(m) != 3 {
+		return ""
+	}
+	return m[1]
+}
+
+// Project returns the project the service account belongs to. For legacy
+// developer.gserviceaccount.com accounts this is the project number prefixed
+// to the name, if any.
+func (e GoogleServiceAccountEmail) Project() string {
+	m := googleServiceAccountEmailRegexp.FindStringSubmatch(string(e))
+	if len(m) != 3 {
+		return ""
+	}
+	if m[2] != "" {
+		return m[2]
+	}
+	if n := googleLegacyServiceAccountRegexp.FindStringSubmatch(m[1]); n != nil {
+		return n[1]
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
+// CLOUD TASKS QUEUE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidGoogleCloudTasksQueue means the configured queue has the wrong format.
+	ErrInvalidGoogleCloudTasksQueue = errors.New("cloud tasks queue is not valid format")
+
+	googleCloudTasksQueueRegexp = regexp.MustCompile(`^projects/([\w-]+)/locations/([a-z][a-z0-9-]*)/queues/([A-Za-z0-9_-]+)$`)
+)
+
+type GoogleCloudTasksQueue struct {
+	ProjectID string
+	Location  string
+	QueueID   string
+}
+
+func (q *GoogleCloudTasksQueue) Set(value string) error {
+	m := googleCloudTasksQueueRegexp.FindStringSubmatch(value)
+	if len(m) != 4 {
+		return ErrInvalidGoogleCloudTasksQueue
+	}
+
+	q.ProjectID = m[1]
+	q.Location = m[2]
+	q.QueueID = m[3]
+
+	return nil
+}
+
+func (q GoogleCloudTasksQueue) String() string {
+	if q == (GoogleCloudTasksQueue{}) {
+		return ""
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/queues/%s", q.ProjectID, q.Location, q.QueueID)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (q GoogleCloudTasksQueue) MarshalText() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// -----------------------------------------------------------------------------
+// CLOUD FUNCTION NAME
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidGoogleCloudFunctionName means the configured function has the wrong format.
+	ErrInvalidGoogleCloudFunctionName = errors.New("cloud function name is not valid format")
+
+	googleCloudFunctionNameRegexp = regexp.MustCompile(`^projects/([\w-]+)/locations/([a-z][a-z0-9-]*)/functions/([A-Za-z0-9_-]{1,63})$`)
+)
+
+type GoogleCloudFunctionName struct {
+	ProjectID    string
+	Location     string
+	FunctionName string
+}
+
+func (f *GoogleCloudFunctionName) Set(value string) error {
+	m := googleCloudFunctionNameRegexp.FindStringSubmatch(value)
+	if len(m) != 4 {
+		return ErrInvalidGoogleCloudFunctionName
+	}
+
+	f.ProjectID = m[1]
+	f.Location = m[2]
+	f.FunctionName = m[3]
+
+	return nil
+}
+
+func (f GoogleCloudFunctionName) String() string {
+	if f == (GoogleCloudFunctionName{}) {
+		return ""
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/functions/%s", f.ProjectID, f.Location, f.FunctionName)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f GoogleCloudFunctionName) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// -----------------------------------------------------------------------------
+// CLOUD SQL INSTANCE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidGoogleCloudSQLInstance means the configured connection name has the wrong format.
+	ErrInvalidGoogleCloudSQLInstance = errors.New("cloud sql instance is not valid format")
+
+	googleCloudSQLInstanceRegexp = regexp.MustCompile(`^([\w-]+):([a-z][a-z0-9-]*):([A-Za-z0-9-]+)$`)
+)
+
+// GoogleCloudSQLInstance is a Cloud SQL instance connection name in the form
+// "project:region:instance".
+type GoogleCloudSQLInstance struct {
+	ProjectID    string
+	Region       string
+	InstanceName string
+}
+
+func (i *GoogleCloudSQLInstance) Set(value string) error {
+	m := googleCloudSQLInstanceRegexp.FindStringSubmatch(value)
+	if len(m) != 4 {
+		return ErrInvalidGoogleCloudSQLInstance
+	}
+
+	i.ProjectID = m[1]
+	i.Region = m[2]
+	i.InstanceName = m[3]
+
+	return nil
+}
+
+// ConnectionName returns the instance connection name used by the Cloud SQL
+// connectors.
+func (i GoogleCloudSQLInstance) ConnectionName() string {
+	return fmt.Sprintf("%s:%s:%s", i.ProjectID, i.Region, i.InstanceName)
+}
+
+func (i GoogleCloudSQLInstance) String() string {
+	if i == (GoogleCloudSQLInstance{}) {
+		return ""
+	}
+	return i.ConnectionName()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (i GoogleCloudSQLInstance) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// -----------------------------------------------------------------------------
+// WORKLOAD IDENTITY POOL
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidGoogleWorkloadIdentityPool means the configured pool has the wrong format.
+	ErrInvalidGoogleWorkloadIdentityPool = errors.New("workload identity pool is not valid format")
+	// ErrInvalidGoogleWorkloadIdentityProvider means the configured provider has the wrong format.
+	ErrInvalidGoogleWorkloadIdentityProvider = errors.New("workload identity provider is not valid format")
+
+	googleWorkloadIdentityPoolRegexp     = regexp.MustCompile(`^projects/([\w-]+)/locations/global/workloadIdentityPools/([a-z0-9-]{4,32})$`)
+	googleWorkloadIdentityProviderRegexp = regexp.MustCompile(`^projects/([\w-]+)/locations/global/workloadIdentityPools/([a-z0-9-]{4,32})/providers/([a-z0-9-]{4,32})$`)
+)
+
+// GoogleWorkloadIdentityPool is a Workload Identity Federation pool in the
+// form "projects/p/locations/global/workloadIdentityPools/pool". Pools always
+// live in the global location.
+type GoogleWorkloadIdentityPool struct {
+	ProjectID string
+	PoolID    string
+}
+
+func (p *GoogleWorkloadIdentityPool) Set(value string) error {
+	m := googleWorkloadIdentityPoolRegexp.FindStringSubmatch(value)
+	if len(m) != 3 {
+		return ErrInvalidGoogleWorkloadIdentityPool
+	}
+
+	p.ProjectID = m[1]
+	p.PoolID = m[2]
+
+	return nil
+}
+
+func (p GoogleWorkloadIdentityPool) String() string {
+	if p == (GoogleWorkloadIdentityPool{}) {
+		return ""
+	}
+	return fmt.Sprintf("projects/%s/locations/global/workloadIdentityPools/%s", p.ProjectID, p.PoolID)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p GoogleWorkloadIdentityPool) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// GoogleWorkloadIdentityProvider is a provider of a Workload Identity
+// Federation pool in the form
+// "projects/p/locations/global/workloadIdentityPools/pool/providers/provider".
+type GoogleWorkloadIdentityProvider struct {
+	ProjectID  string
+	PoolID     string
+	ProviderID string
+}
+
+func (p *GoogleWorkloadIdentityProvider) Set(value string) error {
+	m := googleWorkloadIdentityProviderRegexp.FindStringSubmatch(value)
+	if len(m) != 4 {
+		return ErrInvalidGoogleWorkloadIdentityProvider
+	}
+
+	p.ProjectID = m[1]
+	p.PoolID = m[2]
+	p.ProviderID = m[3]
+
+	return nil
+}
+
+// Pool returns the pool the provider belongs to.
+func (p GoogleWorkloadIdentityProvider) Pool() GoogleWorkloadIdentityPool {
+	return GoogleWorkloadIdentityPool{ProjectID: p.ProjectID, PoolID: p.PoolID}
+}
+
+func (p GoogleWorkloadIdentityProvider) String() string {
+	if p == (GoogleWorkloadIdentityProvider{}) {
+		return ""
+	}
+	return fmt.Sprintf("%s/providers/%s", p.Pool(), p.ProviderID)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p GoogleWorkloadIdentityProvider) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// -----------------------------------------------------------------------------
+// ARTIFACT REGISTRY REPOSITORY
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidGoogleArtifactRegistryRepository means the configured repository has the wrong format.
+	ErrInvalidGoogleArtifactRegistryRepository = errors.New("artifact registry repository is not valid format")
+
+	googleArtifactRegistryRepositoryRegexp     = regexp.MustCompile(`^projects/([\w-]+)/locations/([a-z][a-z0-9-]*)/repositories/([a-z][a-z0-9-]{0,62})$`)
+	googleArtifactRegistryRepositoryHostRegexp = regexp.MustCompile(`^([a-z][a-z0-9-]*)-docker\.pkg\.dev/([\w-]+)/([a-z][a-z0-9-]{0,62})$`)
+)
+
+// GoogleArtifactRegistryRepository is an Artifact Registry repository, given
+// either as the full resource name "projects/p/locations/l/repositories/r" or
+// as the host style path of a Docker repository "l-docker.pkg.dev/p/r".
+type GoogleArtifactRegistryRepository struct {
+	ProjectID      string
+	Location       string
+	RepositoryName string
+}
+
+func (r *GoogleArtifactRegistryRepository) Set(value string) error {
+	if m := googleArtifactRegistryRepositoryRegexp.FindStringSubmatch(value); len(m) == 4 {
+		r.ProjectID = m[1]
+		r.Location = m[2]
+		r.RepositoryName = m[3]
+		return nil
+	}
+	if m := googleArtifactRegistryRepositoryHostRegexp.FindStringSubmatch(value); len(m) == 4 {
+		r.Location = m[1]
+		r.ProjectID = m[2]
+		r.RepositoryName = m[3]
+		return nil
+	}
+
+	return ErrInvalidGoogleArtifactRegistryRepository
+}
+
+// ResourceName returns the full resource name of the repository, whichever
+// form it was configured in.
+func (r GoogleArtifactRegistryRepository) ResourceName() string {
+	return fmt.Sprintf("projects/%s/locations/%s/repositories/%s", r.ProjectID, r.Location, r.RepositoryName)
+}
+
+func (r GoogleArtifactRegistryRepository) String() string {
+	if r == (GoogleArtifactRegistryRepository{}) {
+		return ""
+	}
+	return r.ResourceName()
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the full resource
+// name, which Set accepts, or nothing for the zero value.
+func (r GoogleArtifactRegistryRepository) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// KAFKA BOOTSTRAP SERVERS
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidKafkaBootstrapServers means the configured broker list is empty.
+	ErrInvalidKafkaBootstrapServers = errors.New("kafka bootstrap servers must list at least one broker")
+)
+
+// KafkaBootstrapServers is a comma-separated list of Kafka broker addresses,
+// e.g. "kafka-1:9092,kafka-2:9092". An invalid broker address is reported as
+// ErrInvalidHostPort, wrapped with the index of the broker, and so is an empty
+// entry, such as from a stray comma.
+type KafkaBootstrapServers []HostPort
+
+func (ks *KafkaBootstrapServers) Set(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return ErrInvalidKafkaBootstrapServers
+	}
+
+	var brokers KafkaBootstrapServers
+	for i, broker := range strings.Split(value, ",") {
+		broker = strings.TrimSpace(broker)
+		if broker == "" {
+			// An empty entry is more likely a typo than intent.
+			return fmt.Errorf("broker %d: %w", i, ErrInvalidHostPort)
+		}
+
+		var hp HostPort
+		if err := hp.Set(broker); err != nil {
+			return fmt.Errorf("broker %d: %w", i, err)
+		}
+		brokers = append(brokers, hp)
+	}
+	*ks = brokers
+
+	return nil
+}
+
+// Brokers returns the broker addresses in host:port form, as expected by Kafka
+// client libraries.
+func (ks KafkaBootstrapServers) Brokers() []string {
+	brokers := make([]string, len(ks))
+	for i, hp := range ks {
+		brokers[i] = hp.String()
+	}
+	return brokers
+}
+
+func (ks KafkaBootstrapServers) String() string {
+	return strings.Join(ks.Brokers(), ",")
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// LOCALE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidLocale means the configured value is not a BCP 47 language tag.
+	ErrInvalidLocale = errors.New("locale is not a valid BCP 47 language tag")
+)
+
+// localeRegex matches the structure of a BCP 47 language tag: a language,
+// followed by an optional script, region, variants, extensions and private
+// use subtags.
+var localeRegex = regexp.MustCompile(`^(?i)([a-z]{2,3}(?:-[a-z]{3}){0,3}|[a-z]{4,8})` +
+	`(?:-([a-z]{4}))?` +
+	`(?:-([a-z]{2}|[0-9]{3}))?` +
+	`((?:-(?:[a-z0-9]{5,8}|[0-9][a-z0-9]{3}))*` +
+	`(?:-[0-9a-wyz](?:-[a-z0-9]{2,8})+)*` +
+	`(?:-x(?:-[a-z0-9]{1,8})+)?)$`)
+
+// localePrivateUseRegex matches a BCP 47 tag made up of private use subtags
+// only, such as "x-private".
+var localePrivateUseRegex = regexp.MustCompile(`^(?i)x(?:-[a-z0-9]{1,8})+$`)
+
+// Locale is a BCP 47 language tag, such as "en-US" or "zh-Hans-CN". The
+// POSIX style "en_US" is accepted as well. The subtags are stored in their
+// conventional case, e.g. "en-us" becomes "en-US".
+type Locale struct {
+	language string
+	script   string
+	region   string
+	rest     string
+}
+
+func (l *Locale) Set(value string) error {
+	value = strings.ReplaceAll(value, "_", "-")
+	if localePrivateUseRegex.MatchString(value) {
+		*l = Locale{rest: strings.ToLower(value)}
+		return nil
+	}
+
+	m := localeRegex.FindStringSubmatch(value)
+	if m == nil {
+		return ErrInvalidLocale
+	}
+
+	*l = Locale{
+		language: strings.ToLower(m[1]),
+		region:   strings.ToUpper(m[3]),
+		rest:     strings.ToLower(m[4]),
+	}
+	if m[2] != "" {
+		l.script = strings.ToUpper(m[2][:1]) + strings.ToLower(m[2][1:])
+	}
+
+	return nil
+}
+
+// Language returns the language subtag, such as "en", or "" for a tag of
+// private use subtags only.
+func (l Locale) Language() string {
+	return l.language
+}
+
+// Script returns the script subtag, such as "Hans", or "" if there is none.
+func (l Locale) Script() string {
+	return l.script
+}
+
+// Region returns the region subtag, such as "US", or "" if there is none.
+func (l Locale) Region() string {
+	return l.region
+}
+
+func (l Locale) String() string {
+	if l.language == "" {
+		return l.rest
+	}
+	s := l.language
+	if l.script != "" {
+		s += "-" + l.script
+	}
+	if l.region != "" {
+		s += "-" + l.region
+	}
+	return s + l.rest
+}
@@ -0,0 +1,160 @@
+package types
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// MONGODB URI
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidMongoDBURI means the configured MongoDB URI has the wrong format.
+	ErrInvalidMongoDBURI = errors.New("mongodb uri is not valid format")
+	// ErrUnsupportedMongoDBScheme means the configured URI is neither mongodb:// nor mongodb+srv://.
+	ErrUnsupportedMongoDBScheme = errors.New("mongodb uri scheme is not supported")
+)
+
+// MongoDBURI is a MongoDB connection string such as
+// "mongodb://user:pass@a:27017,b:27017/db?authSource=admin", or
+// "mongodb+srv://cluster.example.com/db" for DNS seed lists.
+type MongoDBURI struct {
+	u *url.URL
+}
+
+func (m *MongoDBURI) Set(value string) error {
+	// url.Parse rejects seed lists in which a host has no port, such as
+	// "a:27017,b", so the hosts are cut out and validated one by one.
+	var hosts string
+	if i := strings.Index(value, "://"); i >= 0 {
+		start, end := i+len("://"), len(value)
+		if j := strings.IndexAny(value[start:], "/?#"); j >= 0 {
+			end = start + j
+		}
+		if j := strings.LastIndex(value[start:end], "@"); j >= 0 {
+			start += j + 1
+		}
+		hosts = value[start:end]
+		value = value[:start] + "hosts" + value[end:]
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return ErrInvalidMongoDBURI
+	}
+	if u.Scheme != "mongodb" && u.Scheme != "mongodb+srv" {
+		return ErrUnsupportedMongoDBScheme
+	}
+	if hosts == "" {
+		return ErrInvalidMongoDBURI
+	}
+	for _, host := range strings.Split(hosts, ",") {
+		port, ok := mongoDBHostPort(host)
+		if !ok {
+			return ErrInvalidMongoDBURI
+		}
+		// A seed list is looked up from a single host name, without a port.
+		if u.Scheme == "mongodb+srv" && (strings.Contains(hosts, ",") || port != "") {
+			return ErrInvalidMongoDBURI
+		}
+	}
+	u.Host = hosts
+
+	m.u = u
+
+	return nil
+}
+
+// mongoDBHostPort validates a host of a MongoDB URI, which may have a port,
+// and returns the port.
+func mongoDBHostPort(host string) (port string, ok bool) {
+	if i := strings.LastIndex(host, ":"); i > strings.LastIndex(host, "]") {
+		host, port = host[:i], host[i+1:]
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return "", false
+		}
+	}
+	return port, host != "" && !strings.ContainsAny(host, " @")
+}
+
+// Hosts returns the hosts of the URI, including their ports if given.
+func (m MongoDBURI) Hosts() []string {
+	if m.u == nil {
+		return nil
+	}
+	return strings.Split(m.u.Host, ",")
+}
+
+// Username returns the user the URI authenticates as, if any.
+func (m MongoDBURI) Username() string {
+	if m.u == nil || m.u.User == nil {
+		return ""
+	}
+	return m.u.User.Username()
+}
+
+// Database returns the database named in the path of the URI, if any.
+func (m MongoDBURI) Database() string {
+	if m.u == nil {
+		return ""
+	}
+	return strings.TrimPrefix(m.u.Path, "/")
+}
+
+// AuthSource returns the database to authenticate against. Like MongoDB
+// drivers, it defaults to the database of the URI, and then to "admin".
+func (m MongoDBURI) AuthSource() string {
+	if m.u == nil {
+		return ""
+	}
+	if source := m.u.Query().Get("authSource"); source != "" {
+		return source
+	}
+	if db := m.Database(); db != "" {
+		return db
+	}
+	return "admin"
+}
+
+// IsSRV reports whether the URI uses the mongodb+srv scheme.
+func (m MongoDBURI) IsSRV() bool {
+	return m.u != nil && m.u.Scheme == "mongodb+srv"
+}
+
+// String returns the URI with the password replaced by "***", so that it is
+// safe to print. MarshalText returns the URI in full.
+func (m MongoDBURI) String() string {
+	if m.u == nil {
+		return ""
+	}
+	if _, ok := m.u.User.Password(); ok {
+		// url.UserPassword would escape the asterisks.
+		u := *m.u
+		u.User = nil
+		userinfo := url.User(m.u.User.Username()).String() + ":***@"
+		return u.Scheme + "://" + userinfo + strings.TrimPrefix(u.String(), u.Scheme+"://")
+	}
+	return m.u.String()
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the URI including
+// its password in the format Set accepts, or nothing for the zero value.
+func (m MongoDBURI) MarshalText() ([]byte, error) {
+	if m.u == nil {
+		return nil, nil
+	}
+	return []byte(m.u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by delegating to Set. An
+// empty text, as MarshalText returns for the zero value, sets the zero value.
+func (m *MongoDBURI) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*m = MongoDBURI{}
+		return nil
+	}
+	return m.Set(string(text))
+}
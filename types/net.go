@@ -0,0 +1,142 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// NETWORK CIDR
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidCIDR means the configured value is not a valid CIDR block.
+	ErrInvalidCIDR = errors.New("cidr is not valid format")
+)
+
+// NetworkCIDR is a CIDR block such as "10.0.0.0/8" or "2001:db8::/32". The
+// network IP and Mask are available directly through the embedded net.IPNet.
+type NetworkCIDR struct {
+	net.IPNet
+}
+
+func (c *NetworkCIDR) Set(value string) error {
+	_, ipnet, err := net.ParseCIDR(value)
+	if err != nil {
+		return ErrInvalidCIDR
+	}
+
+	c.IPNet = *ipnet
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// HOST PORT
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidHostPort means the configured address is not a valid host:port pair.
+	ErrInvalidHostPort = errors.New("address is not valid host:port format")
+)
+
+// HostPort is a network address such as "localhost:9092" or "[::1]:9092".
+type HostPort struct {
+	Host string
+	Port int
+}
+
+func (hp *HostPort) Set(value string) error {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil || host == "" {
+		return ErrInvalidHostPort
+	}
+
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || p == 0 {
+		return ErrInvalidHostPort
+	}
+
+	hp.Host = host
+	hp.Port = int(p)
+
+	return nil
+}
+
+func (hp HostPort) String() string {
+	return net.JoinHostPort(hp.Host, strconv.Itoa(hp.Port))
+}
+
+// -----------------------------------------------------------------------------
+// NETWORK INTERFACE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInterfaceNotFound means the configured network interface does not exist.
+	ErrInterfaceNotFound = errors.New("network interface not found")
+)
+
+// NetworkInterface is the name of a network interface on the host, such as
+// "eth0". Set checks that the interface exists, unless built with the
+// envconfig_nonetcheck build tag, e.g. for binaries configured on another
+// host than they run on.
+type NetworkInterface string
+
+func (ni *NetworkInterface) Set(value string) error {
+	if value == "" || strings.ContainsAny(value, " \t/") {
+		return ErrInterfaceNotFound
+	}
+	if checkInterfaces {
+		if _, err := net.InterfaceByName(value); err != nil {
+			return fmt.Errorf("%w: %w", ErrInterfaceNotFound, err)
+		}
+	}
+
+	*ni = NetworkInterface(value)
+
+	return nil
+}
+
+func (ni NetworkInterface) String() string {
+	return string(ni)
+}
+
+// Interface returns the network interface, looking it up by name.
+func (ni NetworkInterface) Interface() (*net.Interface, error) {
+	return net.InterfaceByName(string(ni))
+}
+
+// -----------------------------------------------------------------------------
+// PORT NUMBER
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidPortNumber means the configured value is not a port between 1 and 65535.
+	ErrInvalidPortNumber = errors.New("port number is not between 1 and 65535")
+)
+
+// PortNumber is a TCP or UDP port between 1 and 65535.
+type PortNumber uint16
+
+func (p *PortNumber) Set(value string) error {
+	n, err := strconv.ParseUint(value, 10, 16)
+	if err != nil || n == 0 {
+		return ErrInvalidPortNumber
+	}
+
+	*p = PortNumber(n)
+
+	return nil
+}
+
+// Value returns the port number.
+func (p PortNumber) Value() uint16 {
+	return uint16(p)
+}
+
+func (p PortNumber) String() string {
+	return strconv.FormatUint(uint64(p), 10)
+}
@@ -0,0 +1,6 @@
+//go:build !envconfig_nonetcheck
+
+package types
+
+// checkInterfaces makes NetworkInterface check that interfaces exist.
+const checkInterfaces = true
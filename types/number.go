@@ -0,0 +1,41 @@
+package types
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// PERCENTAGE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidPercentage means the configured value is not a number between 0 and 100.
+	ErrInvalidPercentage = errors.New("percentage is not a number between 0 and 100")
+)
+
+// Percentage is a number between 0 and 100, optionally written with a
+// trailing "%", e.g. "12.5%".
+type Percentage float64
+
+func (p *Percentage) Set(value string) error {
+	f, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil || math.IsNaN(f) || f < 0 || f > 100 {
+		return ErrInvalidPercentage
+	}
+
+	*p = Percentage(f)
+
+	return nil
+}
+
+// Value returns the percentage as a float64 between 0 and 100.
+func (p Percentage) Value() float64 {
+	return float64(p)
+}
+
+func (p Percentage) String() string {
+	return strconv.FormatFloat(float64(p), 'f', -1, 64) + "%"
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// REDIS DSN
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidRedisDSN means the configured Redis URI has the wrong format.
+	ErrInvalidRedisDSN = errors.New("redis dsn is not valid format")
+)
+
+// RedisDSN is a Redis connection URI such as "redis://:password@host:6379/0",
+// or "rediss://..." for TLS. The port defaults to 6379 and the database to 0.
+type RedisDSN struct {
+	Scheme   string
+	Username string
+	Password string
+	Host     string
+	Port     int
+	DB       int
+}
+
+func (r *RedisDSN) Set(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Hostname() == "" {
+		return ErrInvalidRedisDSN
+	}
+
+	dsn := RedisDSN{
+		Scheme: u.Scheme,
+		Host:   u.Hostname(),
+		Port:   6379,
+	}
+	if u.User != nil {
+		dsn.Username = u.User.Username()
+		dsn.Password, _ = u.User.Password()
+	}
+	if p := u.Port(); p != "" {
+		if dsn.Port, err = strconv.Atoi(p); err != nil || dsn.Port < 1 || dsn.Port > 65535 {
+			return ErrInvalidRedisDSN
+		}
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		if dsn.DB, err = strconv.Atoi(db); err != nil || dsn.DB < 0 {
+			return ErrInvalidRedisDSN
+		}
+	}
+
+	*r = dsn
+
+	return nil
+}
+
+// String returns the DSN with the password masked, like Masked, so that it is
+// safe to print. MarshalText returns the DSN in full.
+func (r RedisDSN) String() string {
+	return r.Masked()
+}
+
+// Masked returns the DSN with the password replaced by "***", for logging.
+func (r RedisDSN) Masked() string {
+	if r == (RedisDSN{}) {
+		return ""
+	}
+	return r.format(url.User(r.Username).String() + ":***")
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the DSN including
+// its password in the format Set accepts, or nothing for the zero value.
+func (r RedisDSN) MarshalText() ([]byte, error) {
+	if r == (RedisDSN{}) {
+		return nil, nil
+	}
+	return []byte(r.format(url.UserPassword(r.Username, r.Password).String())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by delegating to Set. An
+// empty text, as MarshalText returns for the zero value, sets the zero value.
+func (r *RedisDSN) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*r = RedisDSN{}
+		return nil
+	}
+	return r.Set(string(text))
+}
+
+func (r RedisDSN) format(userinfo string) string {
+	if r.Password == "" {
+		userinfo = url.User(r.Username).String()
+	}
+	if userinfo != "" {
+		userinfo += "@"
+	}
+	return r.Scheme + "://" + userinfo + net.JoinHostPort(r.Host, strconv.Itoa(r.Port)) + "/" + strconv.Itoa(r.DB)
+}
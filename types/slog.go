@@ -0,0 +1,112 @@
+package types
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// SLOG LEVEL
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidSlogLevel means the configured log level is not recognized.
+	ErrInvalidSlogLevel = errors.New("log level is not valid")
+)
+
+// SlogLevel is a log/slog level configured by name, e.g. "debug" or "WARN",
+// optionally with an offset in the format of slog.Level.String, such as
+// "DEBUG+3" or "ERROR-1". It implements slog.Leveler, so it can be passed
+// directly to a slog.Handler.
+type SlogLevel slog.Level
+
+// Set parses value strictly, failing with ErrInvalidSlogLevel for an
+// unrecognized level.
+func (l *SlogLevel) Set(value string) error {
+	lvl, err := ParseSlogLevel(value, true)
+	if err != nil {
+		return err
+	}
+
+	*l = lvl
+
+	return nil
+}
+
+// ParseSlogLevel parses a level in the format Set accepts. In strict mode an
+// unrecognized level fails with ErrInvalidSlogLevel, otherwise it falls back to
+// slog.LevelInfo.
+func ParseSlogLevel(value string, strict bool) (SlogLevel, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(value)); err != nil {
+		if strict {
+			return 0, ErrInvalidSlogLevel
+		}
+		return SlogLevel(slog.LevelInfo), nil
+	}
+	return SlogLevel(lvl), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by delegating to Set.
+func (l *SlogLevel) UnmarshalText(text []byte) error {
+	return l.Set(string(text))
+}
+
+// Level implements slog.Leveler.
+func (l SlogLevel) Level() slog.Level {
+	return slog.Level(l)
+}
+
+// String returns the level in the format of slog.Level.String, which Set
+// accepts.
+func (l SlogLevel) String() string {
+	return slog.Level(l).String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l SlogLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// -----------------------------------------------------------------------------
+// LOG FORMAT
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidLogFormat means the configured log format is not recognized.
+	ErrInvalidLogFormat = errors.New("log format is not valid")
+)
+
+var logFormats = map[string]bool{
+	"json":    true,
+	"text":    true,
+	"console": true,
+	"logfmt":  true,
+}
+
+// LogFormat is the output format of a logger: one of "json", "text",
+// "console" or "logfmt", matched case-insensitively.
+type LogFormat struct {
+	Value string
+}
+
+func (f *LogFormat) Set(value string) error {
+	v := strings.ToLower(strings.TrimSpace(value))
+	if !logFormats[v] {
+		return ErrInvalidLogFormat
+	}
+
+	f.Value = v
+
+	return nil
+}
+
+func (f LogFormat) String() string {
+	return f.Value
+}
+
+// IsJSON reports whether the format is "json".
+func (f LogFormat) IsJSON() bool {
+	return f.Value == "json"
+}
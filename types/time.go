@@ -0,0 +1,120 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// TIME ZONE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidTimeZone means the configured value is not a known IANA time zone.
+	ErrInvalidTimeZone = errors.New("time zone is not valid")
+)
+
+// TimeZone is an IANA time zone name such as "America/New_York", or one of
+// the special names "UTC" and "Local".
+type TimeZone struct {
+	loc *time.Location
+}
+
+func (tz *TimeZone) Set(value string) error {
+	switch value {
+	case "UTC":
+		tz.loc = time.UTC
+		return nil
+	case "Local":
+		tz.loc = time.Local
+		return nil
+	case "":
+		// time.LoadLocation treats an empty name as UTC, which is more likely
+		// a mistake than intent.
+		return ErrInvalidTimeZone
+	}
+
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTimeZone, err)
+	}
+
+	tz.loc = loc
+
+	return nil
+}
+
+// Location returns the time zone location, which is UTC if it was never set.
+func (tz TimeZone) Location() *time.Location {
+	if tz.loc == nil {
+		return time.UTC
+	}
+	return tz.loc
+}
+
+func (tz TimeZone) String() string {
+	return tz.Location().String()
+}
+
+// -----------------------------------------------------------------------------
+// INTERVAL
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidInterval means the configured value is not a "min:max" pair of durations.
+	ErrInvalidInterval = errors.New("interval is not valid format")
+	// ErrInvertedInterval means the minimum of the configured interval is greater than its maximum.
+	ErrInvertedInterval = errors.New("interval minimum is greater than maximum")
+)
+
+// Interval is a range of durations written as "min:max", such as "100ms:5s".
+// Like time.Duration fields, each bound may also be a whole number of days,
+// such as "7d".
+type Interval struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+func (i *Interval) Set(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return ErrInvalidInterval
+	}
+
+	lo, err := parseDuration(parts[0])
+	if err != nil {
+		return ErrInvalidInterval
+	}
+	hi, err := parseDuration(parts[1])
+	if err != nil {
+		return ErrInvalidInterval
+	}
+	if lo > hi {
+		return ErrInvertedInterval
+	}
+
+	i.Min = lo
+	i.Max = hi
+
+	return nil
+}
+
+func (i Interval) String() string {
+	return i.Min.String() + ":" + i.Max.String()
+}
+
+// parseDuration parses a duration the way envconfig parses time.Duration
+// fields, accepting a whole number of days with the "d" suffix.
+func parseDuration(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.ParseInt(strings.TrimSuffix(value, "d"), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
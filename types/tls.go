@@ -0,0 +1,65 @@
+package types
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// -----------------------------------------------------------------------------
+// CERTIFICATE FILE
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrCertificateNotFound means the configured certificate file cannot be read.
+	ErrCertificateNotFound = errors.New("certificate file not found")
+	// ErrInvalidPEM means the certificate file holds no PEM block.
+	ErrInvalidPEM = errors.New("certificate file is not valid PEM")
+	// ErrInvalidCertificate means the first PEM block of the certificate file is
+	// not a valid X.509 certificate.
+	ErrInvalidCertificate = errors.New("certificate is not valid")
+)
+
+// CertificateFile is the path to a PEM encoded X.509 certificate, such as one
+// mounted from a Kubernetes secret. Set reads the file and parses its first
+// PEM block, so that a broken TLS configuration fails at startup.
+type CertificateFile struct {
+	path string
+	cert *x509.Certificate
+}
+
+func (c *CertificateFile) Set(value string) error {
+	data, err := os.ReadFile(value)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCertificateNotFound, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return ErrInvalidPEM
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+
+	c.path = value
+	c.cert = cert
+
+	return nil
+}
+
+// Certificate returns the parsed certificate, or nil if none has been set.
+func (c CertificateFile) Certificate() *x509.Certificate {
+	return c.cert
+}
+
+// Path returns the path the certificate was read from.
+func (c CertificateFile) Path() string {
+	return c.path
+}
+
+func (c CertificateFile) String() string {
+	return c.path
+}
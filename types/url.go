@@ -0,0 +1,51 @@
+package types
+
+import (
+	"errors"
+	"net/url"
+)
+
+// -----------------------------------------------------------------------------
+// HTTPS URL
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInsecureScheme means the configured URL does not use the https scheme.
+	ErrInsecureScheme = errors.New("url scheme is not https")
+	// ErrMissingHost means the configured URL has no host.
+	ErrMissingHost = errors.New("url has no host")
+)
+
+// HTTPSUrl is an absolute URL that must use the https scheme.
+type HTTPSUrl struct {
+	u *url.URL
+}
+
+func (h *HTTPSUrl) Set(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" {
+		return ErrInsecureScheme
+	}
+	if u.Host == "" {
+		return ErrMissingHost
+	}
+
+	h.u = u
+
+	return nil
+}
+
+// URL returns the parsed URL, or nil if none has been set.
+func (h HTTPSUrl) URL() *url.URL {
+	return h.u
+}
+
+func (h HTTPSUrl) String() string {
+	if h.u == nil {
+		return ""
+	}
+	return h.u.String()
+}
@@ -10,7 +10,6 @@ import (
 	"io"
 	"os"
 	"reflect"
-	"strconv"
 	"strings"
 	"text/tabwriter"
 	"text/template"
@@ -38,6 +37,7 @@ KEY	TYPE	DEFAULT	REQUIRED	DESCRIPTION
 
 var (
 	decoderType           = reflect.TypeOf((*Decoder)(nil)).Elem()
+	contextSetterType     = reflect.TypeOf((*ContextSetter)(nil)).Elem()
 	setterType            = reflect.TypeOf((*Setter)(nil)).Elem()
 	textUnmarshalerType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
@@ -46,6 +46,8 @@ var (
 func implementsInterface(t reflect.Type) bool {
 	return t.Implements(decoderType) ||
 		reflect.PtrTo(t).Implements(decoderType) ||
+		t.Implements(contextSetterType) ||
+		reflect.PtrTo(t).Implements(contextSetterType) ||
 		t.Implements(setterType) ||
 		reflect.PtrTo(t).Implements(setterType) ||
 		t.Implements(textUnmarshalerType) ||
@@ -132,7 +134,7 @@ func Usagef(prefix string, spec interface{}, out io.Writer, format string) error
 		"usage_required": func(v varInfo) (string, error) {
 			req := v.Tags.Get("required")
 			if req != "" {
-				reqB, err := strconv.ParseBool(req)
+				reqB, err := parseTagBool(req)
 				if err != nil {
 					return "", err
 				}
@@ -155,7 +157,7 @@ func Usagef(prefix string, spec interface{}, out io.Writer, format string) error
 // Usaget writes usage information to the specified io.Writer using the specified template
 func Usaget(prefix string, spec interface{}, out io.Writer, tmpl *template.Template) error {
 	// gather first
-	infos, err := gatherInfo(prefix, spec)
+	infos, err := gatherInfo(prefix, spec, newOptions(nil))
 	if err != nil {
 		return err
 	}
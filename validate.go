@@ -0,0 +1,191 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// Validator is implemented by specifications that need to check invariants
+// spanning several fields, such as a minimum being lower than a maximum.
+type Validator interface {
+	Validate() error
+}
+
+// A ValidationError occurs when the Validate method of a specification fails.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("envconfig.Process: validating specification: %s", e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Processable is implemented by specifications that derive values from their
+// fields, such as a connection string built from a host and a port.
+// PostProcess is called by Process once all fields have been populated.
+type Processable interface {
+	PostProcess() error
+}
+
+// A PostProcessError occurs when the PostProcess method of a specification
+// fails.
+type PostProcessError struct {
+	Err error
+}
+
+func (e *PostProcessError) Error() string {
+	return fmt.Sprintf("envconfig.Process: post-processing specification: %s", e.Err)
+}
+
+func (e *PostProcessError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	validatorsMu sync.RWMutex
+	validators   = make(map[string]func(value string) error)
+)
+
+// RegisterValidator makes a validation function available by name to fields
+// tagged with `validate:"name"`. The function is called with the raw value
+// after it has been converted to the field type, but before the field is
+// populated. Registering a name twice replaces the previous function.
+func RegisterValidator(name string, fn func(value string) error) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	validators[name] = fn
+}
+
+func lookupValidator(name string) func(value string) error {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+	return validators[name]
+}
+
+// processValidated converts value into a copy of the field, runs the named
+// validator and only then populates the field.
+func processValidated(value string, field reflect.Value, seps, name string, o *options) error {
+	fn := lookupValidator(name)
+	if fn == nil {
+		return fmt.Errorf("unknown validator %q", name)
+	}
+
+	tmp := reflect.New(field.Type()).Elem()
+	if err := processField(value, tmp, seps, o); err != nil {
+		return err
+	}
+	if err := fn(value); err != nil {
+		return err
+	}
+	field.Set(tmp)
+
+	return nil
+}
+
+// ValidateSpec checks the struct tags of a specification without reading the
+// environment. It reports fields with conflicting tags, such as a default on
+// an ignored field, length limits that contradict each other, and default
+// values that cannot be converted to the type of their field.
+func ValidateSpec(prefix string, spec interface{}) error {
+	typ := reflect.TypeOf(spec)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		return invalidSpecError(spec)
+	}
+
+	// Work on a fresh value, so that spec is left untouched.
+	o := newOptions(nil)
+	infos, err := gatherInfo(prefix, reflect.New(typ.Elem()).Interface(), o)
+	if err != nil {
+		return err
+	}
+	if err := checkTags(typ.Elem()); err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		def := info.Tags.Get("default")
+		if def == "" || isTrue(info.Tags.Get("file")) {
+			continue
+		}
+		if err := processVar(def, info, o); err != nil {
+			return &ParseError{
+				KeyName:   info.Key,
+				FieldName: info.Name,
+				TypeName:  info.Field.Type().String(),
+				Value:     def,
+				Err:       err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateEnv reads the environment for a specification like Process does, and
+// returns the same errors, without populating any field of spec. It runs on a
+// zero value of the specification, so a Validate method sees only the values
+// from the environment and defaults. Being a dry run, it neither calls
+// PostProcess methods nor the functions given to WithLogger, WithAuditLog and
+// WithMissingHandler. This makes it suitable for pre-flight checks, such as
+// from a readiness probe.
+func ValidateEnv(prefix string, spec interface{}, opts ...Option) error {
+	typ := reflect.TypeOf(spec)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		return invalidSpecError(spec)
+	}
+
+	o := newOptions(opts)
+	o.dryRun = true
+	o.logger, o.auditLog, o.missingHandler = nil, nil, nil
+	_, err := process(prefix, reflect.New(typ.Elem()).Interface(), o)
+	return err
+}
+
+// checkTags looks for contradicting tags on the fields of typ and the structs
+// nested in it.
+func checkTags(typ reflect.Type) error {
+	for i := 0; i < typ.NumField(); i++ {
+		ftype := typ.Field(i)
+		if ftype.PkgPath != "" {
+			continue
+		}
+		if isTrue(ftype.Tag.Get("ignored")) {
+			if ftype.Tag.Get("default") != "" {
+				return fmt.Errorf("%w: field %s has a default but is ignored", ErrConflictingTags, ftype.Name)
+			}
+			continue
+		}
+
+		minLen, maxLen := ftype.Tag.Get("minlength"), ftype.Tag.Get("maxlength")
+		if minLen != "" && maxLen != "" {
+			lo, err := strconv.Atoi(minLen)
+			if err != nil {
+				return fmt.Errorf("envconfig.ValidateSpec: field %s has invalid minlength tag %q", ftype.Name, minLen)
+			}
+			hi, err := strconv.Atoi(maxLen)
+			if err != nil {
+				return fmt.Errorf("envconfig.ValidateSpec: field %s has invalid maxlength tag %q", ftype.Name, maxLen)
+			}
+			if lo > hi {
+				return fmt.Errorf("envconfig.ValidateSpec: field %s has minlength %d greater than maxlength %d", ftype.Name, lo, hi)
+			}
+		}
+
+		t := ftype.Type
+		for t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct && !implementsInterface(t) {
+			if err := checkTags(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
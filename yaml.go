@@ -0,0 +1,51 @@
+package envconfig
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// GenerateYAML documents a specification as a YAML document keyed by
+// environment variable name. Each value is the current value of the variable,
+// falling back to the default and then to null, and the desc tag of a field is
+// written as a comment above it. Values of secret fields are redacted.
+func GenerateYAML(prefix string, spec interface{}) ([]byte, error) {
+	o := newOptions(nil)
+	infos, err := gatherInfo(prefix, spec, o)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, info := range infos {
+		if desc := info.Tags.Get("desc"); desc != "" {
+			for _, line := range strings.Split(desc, "\n") {
+				buf.WriteString("# " + line + "\n")
+			}
+		}
+
+		value, _, err := lookupEnv(info, o)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			if value, err = defaultValue(info, o); err != nil {
+				return nil, err
+			}
+		}
+
+		buf.WriteString(info.Key + ": ")
+		switch {
+		case value == "":
+			buf.WriteString("null")
+		case isTrue(info.Tags.Get("secret")):
+			buf.WriteString(strconv.Quote(redacted))
+		default:
+			buf.WriteString(strconv.Quote(value))
+		}
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes(), nil
+}